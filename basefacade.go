@@ -1,6 +1,8 @@
 package godelbrot
 
 import (
+	"context"
+	"github.com/johnny-morrice/godelbrot/config"
 	"github.com/johnny-morrice/godelbrot/internal/base"
 )
 
@@ -9,6 +11,8 @@ type baseFacade struct {
 
 	pictureWidth  uint
 	pictureHeight uint
+
+	observer func(Progress)
 }
 
 var _ base.RenderApplication = (*baseFacade)(nil)
@@ -19,6 +23,19 @@ func makeBaseFacade(desc *Info) *baseFacade {
 	facade.config = base.BaseConfig{
 		IterateLimit: req.IterateLimit,
 		DivergeLimit: req.DivergeLimit,
+		Julia:        req.Fractal == config.JuliaFractal,
+		Exponent:     req.Exponent,
+
+		CardioidCheck:    req.CardioidCheck,
+		PeriodicityCheck: req.PeriodicityCheck,
+		TrackDerivative:  req.Shading != config.NoShading,
+		TrackInterior:    req.Interior != config.FlatInterior,
+
+		PixelSamples:  req.PixelSamples,
+		JitterSamples: req.Sampling == config.JitterSampling,
+
+		Jobs: req.Jobs,
+		Tile: pictureBounds(desc),
 	}
 	facade.pictureWidth = req.ImageWidth
 	facade.pictureHeight = req.ImageHeight
@@ -32,3 +49,14 @@ func (base *baseFacade) BaseConfig() base.BaseConfig {
 func (base *baseFacade) PictureDimensions() (uint, uint) {
 	return base.pictureWidth, base.pictureHeight
 }
+
+// Observe calls observer with the progress of each later render
+func (base *baseFacade) Observe(observer func(Progress)) {
+	base.observer = observer
+}
+
+// begin a render that stops when ctx is cancelled
+func (base *baseFacade) begin(ctx context.Context) {
+	base.config.Done = ctx.Done()
+	base.config.Tracker = tracker(base.observer)
+}
@@ -0,0 +1,48 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/config"
+	"testing"
+)
+
+// Benchmarks render the default view, with and without the interior checks
+
+func BenchmarkNativeSequence(b *testing.B) {
+	benchmarkDefaultView(b, config.NativeNumericsMode, false)
+}
+
+func BenchmarkNativeSequenceInteriorChecks(b *testing.B) {
+	benchmarkDefaultView(b, config.NativeNumericsMode, true)
+}
+
+func BenchmarkBigSequence(b *testing.B) {
+	benchmarkDefaultView(b, config.BigFloatNumericsMode, false)
+}
+
+func BenchmarkBigSequenceInteriorChecks(b *testing.B) {
+	benchmarkDefaultView(b, config.BigFloatNumericsMode, true)
+}
+
+func benchmarkDefaultView(b *testing.B, numerics config.NumericsMode, checks bool) {
+	req := DefaultRequest()
+	req.Numerics = numerics
+	req.Renderer = config.SequenceRenderMode
+	req.ImageWidth = 100
+	req.ImageHeight = 100
+	req.IterateLimit = 1000
+	req.CardioidCheck = checks
+	req.PeriodicityCheck = checks
+
+	desc, err := Configure(req)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := Render(desc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 type bigCoords struct {
 	userMin   *bigbase.BigComplex
 	userMax   *bigbase.BigComplex
+	juliaSeed *bigbase.BigComplex
+	formula   bigbase.Formula
 	precision uint
 }
 
@@ -17,6 +19,8 @@ func makeBigCoords(desc *Info) *bigCoords {
 	coords.precision = desc.Precision
 	coords.userMin = &bigbase.BigComplex{desc.RealMin, desc.ImagMin}
 	coords.userMax = &bigbase.BigComplex{desc.RealMax, desc.ImagMax}
+	coords.juliaSeed = &bigbase.BigComplex{desc.JuliaReal, desc.JuliaImag}
+	coords.formula = findFormula(desc).big
 	return coords
 }
 
@@ -28,6 +32,14 @@ func (coords *bigCoords) BigUserCoords() (*bigbase.BigComplex, *bigbase.BigCompl
 	return coords.userMin, coords.userMax
 }
 
+func (coords *bigCoords) BigJuliaSeed() *bigbase.BigComplex {
+	return coords.juliaSeed
+}
+
+func (coords *bigCoords) BigFormula() bigbase.Formula {
+	return coords.formula
+}
+
 type bigBaseFacade struct {
 	*baseFacade
 	*bigCoords
@@ -0,0 +1,74 @@
+package godelbrot
+
+import (
+	"context"
+	"fmt"
+	"github.com/johnny-morrice/godelbrot/internal/buddhabrot"
+	"image"
+)
+
+type buddhaFacade struct {
+	*baseFacade
+	*drawFacade
+	*nativeCoords
+	config buddhabrot.BuddhaConfig
+	report RenderReport
+}
+
+// buddhaFacade implements a couple of interfaces
+var _ buddhabrot.RenderApplication = (*buddhaFacade)(nil)
+var _ Renderer = (*buddhaFacade)(nil)
+
+func makeBuddhaFacade(desc *Info) *buddhaFacade {
+	req := desc.UserRequest
+	facade := &buddhaFacade{
+		baseFacade:   makeBaseFacade(desc),
+		drawFacade:   makeDrawFacade(desc),
+		nativeCoords: makeNativeCoords(desc),
+	}
+
+	// Channels without their own limit use the usual iteration limit
+	limits := []uint32{req.RedIterateLimit, req.GreenIterateLimit, req.BlueIterateLimit}
+	facade.config = buddhabrot.BuddhaConfig{
+		Samples: req.BuddhaSamples,
+		Jobs:    req.Jobs,
+	}
+	for ch, limit := range limits {
+		if limit == 0 {
+			limit = req.IterateLimit
+		}
+		facade.config.ChannelLimits[ch] = limit
+	}
+	return facade
+}
+
+func (facade *buddhaFacade) BuddhaConfig() buddhabrot.BuddhaConfig {
+	return facade.config
+}
+
+func (facade *buddhaFacade) Render() (*image.NRGBA, error) {
+	return facade.RenderContext(context.Background())
+}
+
+func (facade *buddhaFacade) RenderContext(ctx context.Context) (*image.NRGBA, error) {
+	facade.begin(ctx)
+	renderer := buddhabrot.Make(facade)
+	picture, err := renderer.Render()
+	return finish(ctx, picture, err)
+}
+
+func (facade *buddhaFacade) RenderProgressive(ctx context.Context, progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	facade.begin(ctx)
+	renderer := buddhabrot.Make(facade)
+	picture, err := renderer.RenderProgressive(snapshots(progress))
+	return finish(ctx, picture, err)
+}
+
+// RenderEscapeMap fails, as the Buddhabrot counts orbits rather than escape values
+func (facade *buddhaFacade) RenderEscapeMap(ctx context.Context) (*EscapeMap, error) {
+	return nil, fmt.Errorf("Buddhabrot cannot render escape maps")
+}
+
+func (facade *buddhaFacade) Report() RenderReport {
+	return facade.report
+}
@@ -5,6 +5,8 @@ import (
 	"image"
 )
 
+// Recolor guesses the escape values of a grayscale picture and draws them with the palette of
+// desc.  Escape values that do not fit in 8 bits are lost; EscapeMap recolours without loss.
 func Recolor(desc *Info, gray image.Image) *image.NRGBA {
 	// CAUTION lossy conversion
 	iterlim := desc.UserRequest.IterateLimit
@@ -18,10 +20,11 @@ func Recolor(desc *Info, gray image.Image) *image.NRGBA {
 	for x := bnd.Min.X; x < bnd.Max.X; x++ {
 		for y := bnd.Min.Y; y < bnd.Max.Y; y++ {
 			bigdiv, _, _, _ := gray.At(x, y).RGBA()
-			invdiv := uint8(bigdiv >> 8)
+			invdiv := graydiv(bigdiv>>8, iterlim)
 			member := base.EscapeValue{
 				InvDiv: invdiv,
 				InSet:  invdiv == iterlim,
+				Smooth: float64(invdiv),
 			}
 			col := palette.Color(member)
 			bright.Set(x, y, col)
@@ -30,3 +33,11 @@ func Recolor(desc *Info, gray image.Image) *image.NRGBA {
 
 	return bright
 }
+
+// Invert the grayscale palette's mapping of escape values onto 8 bit colour.
+func graydiv(gray uint32, iterlim uint32) uint32 {
+	if gray >= 255 {
+		return iterlim
+	}
+	return uint32((uint64(gray) * uint64(iterlim)) / 255)
+}
@@ -3,8 +3,10 @@ package godelbrot
 import (
 	"fmt"
 	"github.com/johnny-morrice/godelbrot/config"
+	"github.com/johnny-morrice/godelbrot/internal/base"
 	bb "github.com/johnny-morrice/godelbrot/internal/bigbase"
 	"log"
+	"math"
 	"math/big"
 )
 
@@ -58,6 +60,110 @@ func (c *configurator) validate() error {
 		return fmt.Errorf("Invalid bounds")
 	}
 
+	req := c.UserRequest
+	switch req.Fractal {
+	case config.MandelbrotFractal:
+	case config.JuliaFractal:
+	default:
+		return fmt.Errorf("Unknown fractal: %v", req.Fractal)
+	}
+
+	if req.Exponent == 1 {
+		return fmt.Errorf("Invalid exponent: %v", req.Exponent)
+	}
+
+	if _, ok := lookupFormula(req.FormulaCode); !ok {
+		return fmt.Errorf("Invalid formula code: %v", req.FormulaCode)
+	}
+
+	switch req.Shading {
+	case config.NoShading:
+	case config.DistanceShading:
+	case config.ReliefShading:
+	default:
+		return fmt.Errorf("Unknown shading: %v", req.Shading)
+	}
+
+	switch req.Interior {
+	case config.FlatInterior:
+	case config.PeriodInterior:
+	case config.DistanceInterior:
+	default:
+		return fmt.Errorf("Unknown interior colouring: %v", req.Interior)
+	}
+
+	switch req.Sampling {
+	case config.GridSampling:
+	case config.JitterSampling:
+	default:
+		return fmt.Errorf("Unknown sampling pattern: %v", req.Sampling)
+	}
+
+	if base.SampleGridSide(req.PixelSamples) == 0 {
+		return fmt.Errorf("Pixel samples must be a square number, not %v", req.PixelSamples)
+	}
+
+	// Only native and big.Float numerics iterate formulae other than z^2 + c
+	if c.generalFormula() && !generalNumerics(req.Numerics) {
+		return fmt.Errorf("Numerics mode %v does not support formula %v with exponent %v",
+			req.Numerics, req.FormulaCode, req.Exponent)
+	}
+
+	// Only native and big.Float numerics track the derivative
+	if req.Shading != config.NoShading && !generalNumerics(req.Numerics) {
+		return fmt.Errorf("Numerics mode %v does not support shading", req.Numerics)
+	}
+
+	if req.Interior != config.FlatInterior && !generalNumerics(req.Numerics) {
+		return fmt.Errorf("Numerics mode %v does not support interior colouring", req.Numerics)
+	}
+
+	if req.Tile != (config.ZoomBounds{}) {
+		if err := req.Tile.Validate(); err != nil {
+			return fmt.Errorf("Invalid tile: %v", err)
+		}
+		if req.Tile.Xmax > req.ImageWidth || req.Tile.Ymax > req.ImageHeight {
+			return fmt.Errorf("Tile lies outside the %vx%v picture", req.ImageWidth, req.ImageHeight)
+		}
+		// The Buddhabrot is scaled by the brightest pixel in the whole picture
+		if req.Renderer == config.BuddhabrotRenderMode {
+			return fmt.Errorf("Buddhabrot cannot render tiles")
+		}
+		// Each tile would be coloured by its own histogram
+		if req.Histogram {
+			return fmt.Errorf("Histogram colouring cannot render tiles")
+		}
+	}
+
+	if req.Renderer == config.BuddhabrotRenderMode {
+		if c.NumericsStrategy != config.NativeNumericsMode {
+			return fmt.Errorf("Buddhabrot requires native numerics")
+		}
+		if req.Fractal != config.MandelbrotFractal {
+			return fmt.Errorf("Buddhabrot requires the Mandelbrot fractal")
+		}
+		if req.BuddhaSamples == 0 {
+			return fmt.Errorf("Buddhabrot requires at least one sample")
+		}
+		if req.Histogram {
+			return fmt.Errorf("Buddhabrot cannot colour by histogram")
+		}
+	}
+
+	if req.SeriesApproximation {
+		// Automatic numerics use the series once the zoom is deep enough for perturbation
+		auto := req.Numerics == config.AutoDetectNumericsMode
+		if !auto && c.NumericsStrategy != config.PerturbationNumericsMode {
+			return fmt.Errorf("Series approximation requires perturbation numerics")
+		}
+		if req.SeriesTerms == 0 {
+			return fmt.Errorf("Series approximation requires at least one term")
+		}
+		if req.SeriesTolerance <= 0.0 {
+			return fmt.Errorf("Invalid series tolerance: %v", req.SeriesTolerance)
+		}
+	}
+
 	return nil
 }
 
@@ -165,6 +271,8 @@ func (c *configurator) chooseRenderStrategy() error {
 		c.useSequenceRenderer()
 	case config.RegionRenderMode:
 		c.useRegionRenderer()
+	case config.BuddhabrotRenderMode:
+		c.RenderStrategy = config.BuddhabrotRenderMode
 	default:
 		return fmt.Errorf("Unknown render mode: %v", req.Renderer)
 	}
@@ -192,6 +300,18 @@ func (c *configurator) chooseNumerics() error {
 		c.selectUserPrec()
 		c.usePrec()
 		c.useBig()
+	case config.PerturbationNumericsMode:
+		c.selectUserPrec()
+		c.usePrec()
+		c.usePerturb()
+	case config.DoubleDoubleNumericsMode:
+		c.selectUserPrec()
+		c.usePrec()
+		c.useDoubleDouble()
+	case config.FixedPointNumericsMode:
+		c.selectUserPrec()
+		c.usePrec()
+		c.useFixed()
 	default:
 		return fmt.Errorf("Unknown numerics mode:", desc.Numerics)
 	}
@@ -211,25 +331,31 @@ func (c *configurator) selectUserPrec() {
 func (c *configurator) chooseAccurateNumerics() {
 	// 53 bits precision is available to 64 bit floats
 	const prec64 uint = 53
+	// Double-double has 106 bits, but leave headroom for rounding error to accumulate
+	const precDD uint = 100
 
 	c.selectUserPrec()
 	c.usePrec()
-	if c.Precision > prec64 {
-		c.useBig()
+	// The Buddhabrot plots whole orbits, so is only useful at native precision
+	if c.UserRequest.Renderer == config.BuddhabrotRenderMode {
+		c.useNative()
+	} else if c.generalFormula() || c.trackingNumerics() {
+		if c.Precision > prec64 {
+			c.useBig()
+		} else {
+			c.useNative()
+		}
+	} else if c.Precision > precDD {
+		c.usePerturb()
+	} else if c.Precision > prec64 {
+		c.useDoubleDouble()
 	} else {
 		c.useNative()
 	}
 }
 
 func (c *configurator) usePrec() {
-	bounds := []*big.Float{
-		&c.RealMin,
-		&c.RealMax,
-		&c.ImagMin,
-		&c.ImagMax,
-	}
-
-	for _, num := range bounds {
+	for _, num := range (*Info)(c).bignums() {
 		// I say c.Precision rather than bits because I think these should be equal
 		// and if there is a bug, this will certainly break quicker.
 		num.SetPrec(c.Precision)
@@ -244,6 +370,18 @@ func (c *configurator) useBig() {
 	c.NumericsStrategy = config.BigFloatNumericsMode
 }
 
+func (c *configurator) usePerturb() {
+	c.NumericsStrategy = config.PerturbationNumericsMode
+}
+
+func (c *configurator) useDoubleDouble() {
+	c.NumericsStrategy = config.DoubleDoubleNumericsMode
+}
+
+func (c *configurator) useFixed() {
+	c.NumericsStrategy = config.FixedPointNumericsMode
+}
+
 func (c *configurator) parseUserCoords() error {
 	bigActions := []func(*big.Float){
 		func(realMin *big.Float) { c.RealMin = *realMin },
@@ -273,6 +411,17 @@ func (c *configurator) parseUserCoords() error {
 		bigActions[i](bigFloat)
 	}
 
+	juliaReal, rerr := parseOptionalBig(desc.JuliaReal)
+	if rerr != nil {
+		return fmt.Errorf("Could not parse juliaReal: %v", rerr)
+	}
+	juliaImag, ierr := parseOptionalBig(desc.JuliaImag)
+	if ierr != nil {
+		return fmt.Errorf("Could not parse juliaImag: %v", ierr)
+	}
+	c.JuliaReal = *juliaReal
+	c.JuliaImag = *juliaImag
+
 	return nil
 }
 
@@ -291,6 +440,12 @@ func (c *configurator) chooseFastRenderStrategy() {
 	weirdbase := numerics != config.NativeNumericsMode
 	squarepic := req.ImageWidth == req.ImageHeight
 
+	// Uniform regions would be filled with flat bands of colour
+	if req.Smooth || req.Shading != config.NoShading {
+		c.useSequenceRenderer()
+		return
+	}
+
 	if (bigsz || weirdbase) && squarepic {
 		c.useRegionRenderer()
 	} else {
@@ -315,6 +470,11 @@ func (c *configurator) howManyBits() uint {
 		c.ImagMax,
 	}
 
+	// The Julia constant needs the same care as the plane
+	if c.UserRequest.Fractal == config.JuliaFractal {
+		bounds = append(bounds, c.JuliaReal, c.JuliaImag)
+	}
+
 	bits := uint(0)
 	for _, bnd := range bounds {
 		prec := bnd.MinPrec()
@@ -326,18 +486,45 @@ func (c *configurator) howManyBits() uint {
 	return bits
 }
 
-func (c *configurator) choosePalette() error {
-	code := c.UserRequest.PaletteCode
-	switch code {
-	case "pretty":
-		c.PaletteType = Pretty
-	case "redscale":
-		c.PaletteType = Redscale
-	case "grayscale":
-		c.PaletteType = Grayscale
+// generalFormula is true when the formula is anything other than z^2 + c
+func (c *configurator) generalFormula() bool {
+	req := c.UserRequest
+	code := req.FormulaCode
+	mandelbrot := code == "" || code == DefaultFormulaCode
+	return req.Exponent > 2 || !mandelbrot
+}
+
+// trackingNumerics is true when the render needs more than the escape value of each point
+func (c *configurator) trackingNumerics() bool {
+	req := c.UserRequest
+	return req.Shading != config.NoShading || req.Interior != config.FlatInterior
+}
+
+// generalNumerics is true when the numerics mode may iterate any formula
+func generalNumerics(mode config.NumericsMode) bool {
+	switch mode {
+	case config.AutoDetectNumericsMode:
+	case config.NativeNumericsMode:
+	case config.BigFloatNumericsMode:
 	default:
-		return fmt.Errorf("Invalid palette code: %v", code)
+		return false
+	}
+	return true
+}
+
+func (c *configurator) choosePalette() error {
+	if _, err := makePalette(&c.UserRequest); err != nil {
+		return err
 	}
 
+	offset := c.UserRequest.PaletteOffset
+	if math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return fmt.Errorf("Invalid palette offset: %v", offset)
+	}
+
+	// Palettes registered by library users have no PaletteKind
+	if kind, ok := paletteKinds[c.UserRequest.PaletteCode]; ok {
+		c.PaletteType = kind
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"image"
 )
 
 type AspectConservation uint8
@@ -14,7 +15,7 @@ const (
 
 // Request is a user description of the render to be accomplished
 type Request struct {
-	IterateLimit uint8
+	IterateLimit uint32
 	DivergeLimit float64
 	RealMin      string
 	RealMax      string
@@ -23,6 +24,12 @@ type Request struct {
 	ImageWidth   uint
 	ImageHeight  uint
 	PaletteCode  string
+	// Colours of the "gradient" palette
+	Gradient *Gradient
+	// Phase of the palette, as a fraction of its cycle
+	PaletteOffset float64
+	// Iterations in one cycle of the palette.  Zero means the iteration limit.
+	PaletteCycle uint32
 	FixAspect    AspectConservation
 	// Render algorithm
 	Renderer RenderMode
@@ -35,8 +42,92 @@ type Request struct {
 	RegionSamples uint
 	// Number of bits for big.Float rendering
 	Precision uint
+	// Interpolate colours using the continuous escape value
+	Smooth bool
+	// Spread colours evenly over the picture by the histogram of escape values
+	Histogram bool
+	// Skip early iterations using a series approximation in the distance from the reference
+	// point.  Only perturbation numerics use it; big.Float numerics iterate every point fully.
+	SeriesApproximation bool
+	// Number of terms in the approximating series
+	SeriesTerms uint
+	// Maximum relative error permitted in the approximation
+	SeriesTolerance float64
+	// Kind of fractal to render
+	Fractal FractalKind
+	// Constant for Julia sets, where each pixel gives the initial value of z
+	JuliaReal string
+	JuliaImag string
+	// Power of z in z^n + c, for Multibrot sets.  Zero means the usual Mandelbrot exponent 2.
+	Exponent uint
+	// Escape-time formula, such as "mandelbrot" or "burningship".  Empty means "mandelbrot".
+	FormulaCode string
+	// Skip points inside the main cardioid and period-2 bulb (Mandelbrot set only)
+	CardioidCheck bool
+	// Stop iterating points whose orbits are found to cycle
+	PeriodicityCheck bool
+	// Shade escaping points using the distance estimate
+	Shading ShadingMode
+	// Colour points inside the set
+	Interior InteriorMode
+	// Number of random points whose orbits are plotted by the Buddhabrot renderer
+	BuddhaSamples uint
+	// Iteration limits for each colour channel of the Buddhabrot renderer.  Zero means
+	// IterateLimit.  Different limits give the Nebulabrot.
+	RedIterateLimit   uint32
+	GreenIterateLimit uint32
+	BlueIterateLimit  uint32
+	// Number of samples averaged for each pixel, which must be a square number.  Zero or one
+	// disables supersampling.
+	PixelSamples uint
+	// Placement of the samples within each pixel
+	Sampling SamplingPattern
+	// Tile of the picture to render, in pixels.  Plane coordinates are those of the whole
+	// picture.  The zero value renders the whole picture.
+	Tile ZoomBounds
 }
 
+// Available fractals
+type FractalKind uint
+
+const (
+	MandelbrotFractal = FractalKind(iota)
+	JuliaFractal
+)
+
+// Available shading effects, which use the distance estimate
+type ShadingMode uint
+
+const (
+	NoShading = ShadingMode(iota)
+	// Darken thin filaments close to the set boundary
+	DistanceShading
+	// Light the escape value as a surface, giving a relief effect
+	ReliefShading
+)
+
+// Available colourings for points inside the set
+type InteriorMode uint
+
+const (
+	// Colour all points inside the set alike
+	FlatInterior = InteriorMode(iota)
+	// Colour points by the period of their attracting cycle
+	PeriodInterior
+	// Colour points by period, shaded by distance to the boundary of their hyperbolic component
+	DistanceInterior
+)
+
+// Available placements of supersamples within a pixel
+type SamplingPattern uint
+
+const (
+	// Samples lie at the centres of a regular grid of subpixels
+	GridSampling = SamplingPattern(iota)
+	// Each sample lies at a random point in its subpixel
+	JitterSampling
+)
+
 // Available render algorithms
 type RenderMode uint
 
@@ -44,6 +135,8 @@ const (
 	AutoDetectRenderMode = RenderMode(iota)
 	RegionRenderMode
 	SequenceRenderMode
+	// Plot the density of escaping orbits
+	BuddhabrotRenderMode
 )
 
 // Available numeric systems
@@ -56,8 +149,15 @@ const (
 	NativeNumericsMode
 	// Use arithmetic based around the standard library big.Float type
 	BigFloatNumericsMode
+	// Iterate native deltas from a big.Float reference orbit
+	PerturbationNumericsMode
+	// Use pairs of native floats for roughly 106 bits of precision
+	DoubleDoubleNumericsMode
+	// Use fixed-point arithmetic based around the standard library big.Int type
+	FixedPointNumericsMode
 )
 
+// A rectangle of pixels within the picture.  The maximums are exclusive.
 type ZoomBounds struct {
 	Xmin uint
 	Xmax uint
@@ -72,6 +172,11 @@ func (zb *ZoomBounds) Validate() error {
 	return nil
 }
 
+// Rect returns the bounds as an image rectangle
+func (zb *ZoomBounds) Rect() image.Rectangle {
+	return image.Rect(int(zb.Xmin), int(zb.Ymin), int(zb.Xmax), int(zb.Ymax))
+}
+
 type ZoomTarget struct {
 	ZoomBounds
 	// Reconsider numerical system and render modes as appropriate.
@@ -81,4 +186,6 @@ type ZoomTarget struct {
 	UpPrec bool
 	// Number of frames for zoom
 	Frames uint
+	// Change in palette offset from the first frame to the last, for colour cycling
+	PaletteShift float64
 }
@@ -0,0 +1,22 @@
+package config
+
+// Gradient is a user description of a palette that blends colours between control points.
+// Colours are written #rrggbb, or #rrggbbaa with transparency.
+type Gradient struct {
+	// Colour space in which colours are blended (rgb|hsv|lab|lch).  Empty means rgb.
+	Space string
+	// What lies beyond the end of the gradient (clamp|repeat|mirror).  Empty means clamp.
+	Mode string
+	// Number of times the gradient spans the iteration range.  Zero means 1.
+	Repeats float64
+	// Colour of points in the set.  Empty means opaque black.
+	InSet string
+	Stops []GradientStop
+}
+
+// GradientStop is a control point of a Gradient
+type GradientStop struct {
+	// Position from 0 to 1
+	Position float64
+	Color    string
+}
@@ -0,0 +1,236 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/config"
+	"testing"
+)
+
+func TestChooseAccurateNumerics(t *testing.T) {
+	expect := map[uint]config.NumericsMode{
+		53:  config.NativeNumericsMode,
+		54:  config.DoubleDoubleNumericsMode,
+		100: config.DoubleDoubleNumericsMode,
+		101: config.PerturbationNumericsMode,
+	}
+
+	for prec, mode := range expect {
+		req := DefaultRequest()
+		req.Precision = prec
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if desc.NumericsStrategy != mode {
+			t.Error("At precision", prec, "expected numerics", mode, "but received", desc.NumericsStrategy)
+		}
+	}
+}
+
+func TestChooseMultibrotNumerics(t *testing.T) {
+	expect := map[uint]config.NumericsMode{
+		53: config.NativeNumericsMode,
+		54: config.BigFloatNumericsMode,
+	}
+
+	for prec, mode := range expect {
+		req := DefaultMultibrotRequest(3)
+		req.Precision = prec
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if desc.NumericsStrategy != mode {
+			t.Error("At precision", prec, "expected numerics", mode, "but received", desc.NumericsStrategy)
+		}
+	}
+
+	req := DefaultMultibrotRequest(3)
+	req.Numerics = config.PerturbationNumericsMode
+	_, err := Configure(req)
+	if err == nil {
+		t.Error("Expected error configuring Multibrot set with perturbation numerics")
+	}
+}
+
+func TestMultibrotBounds(t *testing.T) {
+	min, max := MultibrotBounds(2)
+	if min != MandelbrotMin || max != MandelbrotMax {
+		t.Error("Expected Mandelbrot bounds but received", min, max)
+	}
+
+	// The cubic Multibrot set lies within the disk of radius sqrt(2)
+	min, max = MultibrotBounds(3)
+	if min != -1.5-1.5i || max != 1.5+1.5i {
+		t.Error("Unexpected cubic Multibrot bounds", min, max)
+	}
+}
+
+func TestJuliaBounds(t *testing.T) {
+	min, max := JuliaBounds(2)
+	if min != JuliaMin || max != JuliaMax {
+		t.Error("Expected Julia bounds but received", min, max)
+	}
+
+	min, max = JuliaBounds(3)
+	if min != -1.5-1.5i || max != 1.5+1.5i {
+		t.Error("Unexpected cubic Julia bounds", min, max)
+	}
+}
+
+func TestConfigureInterior(t *testing.T) {
+	expect := map[uint]config.NumericsMode{
+		53: config.NativeNumericsMode,
+		54: config.BigFloatNumericsMode,
+	}
+
+	for prec, mode := range expect {
+		req := DefaultRequest()
+		req.Interior = config.DistanceInterior
+		req.Precision = prec
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if desc.NumericsStrategy != mode {
+			t.Error("At precision", prec, "expected numerics", mode, "but received", desc.NumericsStrategy)
+		}
+	}
+
+	req := DefaultRequest()
+	req.Interior = config.PeriodInterior
+	req.Numerics = config.PerturbationNumericsMode
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error configuring interior colouring with perturbation numerics")
+	}
+
+	req = DefaultRequest()
+	req.Interior = config.InteriorMode(100)
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for unknown interior colouring")
+	}
+}
+
+func TestConfigureBuddhabrot(t *testing.T) {
+	req := DefaultRequest()
+	req.Renderer = config.BuddhabrotRenderMode
+	req.Precision = 80
+
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if desc.NumericsStrategy != config.NativeNumericsMode {
+		t.Error("Expected native numerics for Buddhabrot, but received", desc.NumericsStrategy)
+	}
+	if desc.RenderStrategy != config.BuddhabrotRenderMode {
+		t.Error("Expected Buddhabrot render strategy, but received", desc.RenderStrategy)
+	}
+
+	req = DefaultRequest()
+	req.Renderer = config.BuddhabrotRenderMode
+	req.Numerics = config.BigFloatNumericsMode
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error configuring Buddhabrot with big.Float numerics")
+	}
+
+	req = DefaultRequest()
+	req.Renderer = config.BuddhabrotRenderMode
+	req.BuddhaSamples = 0
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error configuring Buddhabrot without samples")
+	}
+}
+
+func TestConfigureSeries(t *testing.T) {
+	modes := []config.NumericsMode{
+		config.AutoDetectNumericsMode,
+		config.PerturbationNumericsMode,
+	}
+	for _, mode := range modes {
+		req := DefaultRequest()
+		req.Numerics = mode
+		req.SeriesApproximation = true
+		if _, err := Configure(req); err != nil {
+			t.Error("Unexpected error for series approximation with numerics", mode, ":", err)
+		}
+	}
+
+	req := DefaultRequest()
+	req.Numerics = config.BigFloatNumericsMode
+	req.SeriesApproximation = true
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for series approximation with big.Float numerics")
+	}
+
+	req = DefaultRequest()
+	req.SeriesApproximation = true
+	req.SeriesTerms = 0
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for series approximation without terms")
+	}
+}
+
+func TestConfigureSupersampling(t *testing.T) {
+	for _, samples := range []uint{0, 1, 4, 9} {
+		req := DefaultRequest()
+		req.PixelSamples = samples
+		req.Sampling = config.JitterSampling
+		if _, err := Configure(req); err != nil {
+			t.Error("Unexpected error for", samples, "pixel samples:", err)
+		}
+	}
+
+	req := DefaultRequest()
+	req.PixelSamples = 5
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for pixel samples that are not square")
+	}
+
+	req = DefaultRequest()
+	req.Sampling = config.SamplingPattern(100)
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for unknown sampling pattern")
+	}
+}
+
+func TestConfigureTile(t *testing.T) {
+	req := DefaultRequest()
+	req.Tile = config.ZoomBounds{Xmin: 0, Xmax: req.ImageWidth, Ymin: 10, Ymax: 20}
+	if _, err := Configure(req); err != nil {
+		t.Error("Unexpected error for tile:", err)
+	}
+
+	bad := []config.ZoomBounds{
+		config.ZoomBounds{Xmin: 10, Xmax: 10, Ymin: 0, Ymax: 10},
+		config.ZoomBounds{Xmin: 0, Xmax: req.ImageWidth + 1, Ymin: 0, Ymax: 10},
+		config.ZoomBounds{Xmin: 0, Xmax: 10, Ymin: 0, Ymax: req.ImageHeight + 1},
+	}
+	for _, tile := range bad {
+		req := DefaultRequest()
+		req.Tile = tile
+		if _, err := Configure(req); err == nil {
+			t.Error("Expected error for tile", tile)
+		}
+	}
+
+	req = DefaultRequest()
+	req.Renderer = config.BuddhabrotRenderMode
+	req.Tile = config.ZoomBounds{Xmin: 0, Xmax: 10, Ymin: 0, Ymax: 10}
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for Buddhabrot tile")
+	}
+
+	req = DefaultRequest()
+	req.Histogram = true
+	req.Tile = config.ZoomBounds{Xmin: 0, Xmax: 10, Ymin: 0, Ymax: 10}
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for histogram tile")
+	}
+}
@@ -1,6 +1,7 @@
 package godelbrot
 
 import (
+	"context"
 	"fmt"
 	"github.com/johnny-morrice/godelbrot/config"
 	"image"
@@ -8,6 +9,19 @@ import (
 
 type Renderer interface {
 	Render() (*image.NRGBA, error)
+	// RenderContext renders until done, or until ctx is cancelled, when it returns ctx.Err().
+	RenderContext(ctx context.Context) (*image.NRGBA, error)
+	// RenderProgressive renders in passes of increasing detail, calling progress with a
+	// snapshot of the picture after each pass but the last.  It stops when ctx is cancelled.
+	RenderProgressive(ctx context.Context, progress func(*image.NRGBA)) (*image.NRGBA, error)
+	// RenderEscapeMap renders the escape value of every sample, rather than a picture.  It
+	// stops when ctx is cancelled.
+	RenderEscapeMap(ctx context.Context) (*EscapeMap, error)
+	// Observe calls observer with the progress of each later render.  The Buddhabrot does not
+	// report progress.
+	Observe(observer func(Progress))
+	// Report describes the most recent render
+	Report() RenderReport
 }
 
 func MakeRenderer(desc *Info) (Renderer, error) {
@@ -15,6 +29,9 @@ func MakeRenderer(desc *Info) (Renderer, error) {
 	switch desc.NumericsStrategy {
 	case config.NativeNumericsMode:
 	case config.BigFloatNumericsMode:
+	case config.PerturbationNumericsMode:
+	case config.DoubleDoubleNumericsMode:
+	case config.FixedPointNumericsMode:
 	default:
 		return nil, fmt.Errorf("Invalid NumericsStrategy: %v", desc.NumericsStrategy)
 	}
@@ -33,9 +50,32 @@ func MakeRenderer(desc *Info) (Renderer, error) {
 		renderer = makeSequenceFacade(desc)
 	case config.RegionRenderMode:
 		renderer = makeRegionFacade(desc)
+	case config.BuddhabrotRenderMode:
+		renderer = makeBuddhaFacade(desc)
 	default:
 		return nil, fmt.Errorf("Invalid RenderStrategy: %v", desc.RenderStrategy)
 	}
 
 	return renderer, nil
 }
+
+// snapshots wraps progress so that it receives copies of the picture, which the renderer
+// goes on to change.
+func snapshots(progress func(*image.NRGBA)) func(*image.NRGBA) {
+	return func(picture *image.NRGBA) {
+		snapshot := image.NewNRGBA(picture.Rect)
+		copy(snapshot.Pix, picture.Pix)
+		progress(snapshot)
+	}
+}
+
+// finish returns the picture, unless ctx was cancelled before the render completed.
+func finish(ctx context.Context, picture *image.NRGBA, err error) (*image.NRGBA, error) {
+	if err != nil {
+		return nil, err
+	}
+	if cerr := ctx.Err(); cerr != nil {
+		return nil, cerr
+	}
+	return picture, nil
+}
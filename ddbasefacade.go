@@ -0,0 +1,43 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+)
+
+type ddCoords struct {
+	userMin   ddbase.DDComplex
+	userMax   ddbase.DDComplex
+	juliaSeed ddbase.DDComplex
+}
+
+var _ ddbase.DDCoordProvider = (*ddCoords)(nil)
+
+func (coords *ddCoords) DDUserCoords() (ddbase.DDComplex, ddbase.DDComplex) {
+	return coords.userMin, coords.userMax
+}
+
+func (coords *ddCoords) DDJuliaSeed() ddbase.DDComplex {
+	return coords.juliaSeed
+}
+
+func makeDDCoords(desc *Info) *ddCoords {
+	coords := &ddCoords{}
+	coords.userMin = ddbase.FromBigComplex(&desc.RealMin, &desc.ImagMin)
+	coords.userMax = ddbase.FromBigComplex(&desc.RealMax, &desc.ImagMax)
+	coords.juliaSeed = ddbase.FromBigComplex(&desc.JuliaReal, &desc.JuliaImag)
+	return coords
+}
+
+type ddBaseFacade struct {
+	*baseFacade
+	*ddCoords
+}
+
+var _ ddbase.RenderApplication = (*ddBaseFacade)(nil)
+
+func makeDDBaseFacade(desc *Info, baseApp *baseFacade) *ddBaseFacade {
+	facade := &ddBaseFacade{}
+	facade.baseFacade = baseApp
+	facade.ddCoords = makeDDCoords(desc)
+	return facade
+}
@@ -0,0 +1,21 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/ddregion"
+)
+
+type ddRegionFacade struct {
+	*baseFacade
+	*regionProvider
+	*ddCoords
+}
+
+var _ ddregion.RenderApplication = (*ddRegionFacade)(nil)
+
+func makeDDRegionFacade(desc *Info, baseApp *baseFacade, regionDesc *regionProvider) *ddRegionFacade {
+	facade := &ddRegionFacade{}
+	facade.baseFacade = baseApp
+	facade.regionProvider = regionDesc
+	facade.ddCoords = makeDDCoords(desc)
+	return facade
+}
@@ -1,14 +1,18 @@
 package godelbrot
 
 import (
+	"github.com/johnny-morrice/godelbrot/config"
+	"github.com/johnny-morrice/godelbrot/internal/base"
 	"github.com/johnny-morrice/godelbrot/internal/draw"
 	"image"
 	"log"
 )
 
 type drawFacade struct {
+	desc    *Info
 	picture *image.NRGBA
 	colors  draw.Palette
+	escapes *draw.EscapeMap
 }
 
 var _ draw.DrawingContext = (*drawFacade)(nil)
@@ -26,35 +30,86 @@ func (facade *drawFacade) Picture() *image.NRGBA {
 	return facade.picture
 }
 
+func (facade *drawFacade) Escapes() *draw.EscapeMap {
+	return facade.escapes
+}
+
 func makeDrawFacade(desc *Info) *drawFacade {
-	facade := &drawFacade{}
+	facade := &drawFacade{desc: desc}
 	facade.colors = createStoredPalette(desc)
 	facade.picture = createImage(desc)
 	return facade
 }
 
 func createImage(desc *Info) *image.NRGBA {
+	return image.NewNRGBA(pictureBounds(desc))
+}
+
+// pictureBounds returns the tile of the picture that is rendered
+func pictureBounds(desc *Info) image.Rectangle {
 	req := desc.UserRequest
-	bounds := image.Rectangle{
-		Min: image.ZP,
-		Max: image.Point{
-			X: int(req.ImageWidth),
-			Y: int(req.ImageHeight),
-		},
+	if req.Tile != (config.ZoomBounds{}) {
+		return req.Tile.Rect()
 	}
-	return image.NewNRGBA(bounds)
+	return image.Rect(0, 0, int(req.ImageWidth), int(req.ImageHeight))
 }
 
 func createStoredPalette(desc *Info) draw.Palette {
-	palettes := map[PaletteKind]draw.PaletteFactory{
-		Redscale:  draw.NewRedscalePalette,
-		Pretty:    draw.NewPrettyPalette,
-		Grayscale: draw.NewGrayscalePalette,
+	palette := createScale(desc)
+
+	if desc.UserRequest.Smooth {
+		palette = draw.NewSmoothPalette(interpolator(desc, palette))
 	}
-	kind := desc.PaletteType
-	found := palettes[kind]
-	if found == nil {
-		log.Panic("Unknown PaletteKind:", kind)
+
+	return decoratePalette(desc, palette)
+}
+
+// createHistogramPalette creates a palette that colours by the histogram of escape values
+func createHistogramPalette(desc *Info, values []base.EscapeValue) draw.Palette {
+	req := desc.UserRequest
+	interp := interpolator(desc, createScale(desc))
+	palette := draw.NewHistogramPalette(interp, req.IterateLimit, values, req.Smooth)
+	return decoratePalette(desc, palette)
+}
+
+// createScale creates the registered palette of the Info, cycled by its offset and cycle length
+func createScale(desc *Info) draw.Palette {
+	req := desc.UserRequest
+	req.PaletteCode = paletteCode(desc)
+	palette, err := makePalette(&req)
+	if err != nil {
+		log.Panic("Invalid palette:", err)
 	}
-	return found(desc.UserRequest.IterateLimit)
+
+	if req.PaletteOffset != 0 || req.PaletteCycle != 0 {
+		return draw.NewCyclePalette(palette, req.IterateLimit, req.PaletteCycle, req.PaletteOffset)
+	}
+	return palette
+}
+
+func interpolator(desc *Info, palette draw.Palette) draw.Interpolator {
+	interp, ok := palette.(draw.Interpolator)
+	if !ok {
+		log.Panic("Palette cannot interpolate:", paletteCode(desc))
+	}
+	return interp
+}
+
+// decoratePalette adds interior colouring and shading to the palette
+func decoratePalette(desc *Info, palette draw.Palette) draw.Palette {
+	switch desc.UserRequest.Interior {
+	case config.PeriodInterior:
+		palette = draw.NewPeriodPalette(palette)
+	case config.DistanceInterior:
+		palette = draw.NewInteriorDistancePalette(draw.NewPeriodPalette(palette))
+	}
+
+	switch desc.UserRequest.Shading {
+	case config.DistanceShading:
+		palette = draw.NewDistancePalette(palette)
+	case config.ReliefShading:
+		palette = draw.NewReliefPalette(palette)
+	}
+
+	return palette
 }
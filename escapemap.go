@@ -0,0 +1,267 @@
+package godelbrot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"image"
+	"io"
+	"io/ioutil"
+	"math"
+)
+
+// EscapeMap holds the escape value of every sample of a render, so that it may be coloured
+// again with any palette, without loss.
+type EscapeMap struct {
+	// Info describes the render
+	Info    Info
+	escapes *draw.EscapeMap
+}
+
+// Bounds returns the pixels of the picture in the escape map
+func (em *EscapeMap) Bounds() image.Rectangle {
+	return em.escapes.Rect
+}
+
+// Recolor draws the escape map with the palette, smoothing, interior colouring and shading of
+// style.  Nil style draws it as it was rendered.
+func (em *EscapeMap) Recolor(style *Info) *image.NRGBA {
+	desc := em.Info
+	if style != nil {
+		desc.PaletteType = style.PaletteType
+		desc.UserRequest.PaletteCode = style.UserRequest.PaletteCode
+		desc.UserRequest.Gradient = style.UserRequest.Gradient
+		desc.UserRequest.PaletteOffset = style.UserRequest.PaletteOffset
+		desc.UserRequest.PaletteCycle = style.UserRequest.PaletteCycle
+		desc.UserRequest.Smooth = style.UserRequest.Smooth
+		desc.UserRequest.Histogram = style.UserRequest.Histogram
+		desc.UserRequest.Interior = style.UserRequest.Interior
+		desc.UserRequest.Shading = style.UserRequest.Shading
+	}
+
+	picture := image.NewNRGBA(em.escapes.Rect)
+	em.escapes.Draw(escapePalette(&desc, em.escapes), picture)
+	return picture
+}
+
+// escapePalette creates the palette described by desc for the escape map
+func escapePalette(desc *Info, escapes *draw.EscapeMap) draw.Palette {
+	if desc.UserRequest.Histogram {
+		return createHistogramPalette(desc, escapes.Values)
+	}
+	return createStoredPalette(desc)
+}
+
+// RenderEscapeMap renders the escape values described by info, until done, or until ctx is
+// cancelled, when it returns ctx.Err().
+func RenderEscapeMap(ctx context.Context, info *Info) (*EscapeMap, error) {
+	renderer, err := MakeRenderer(info)
+	if err == nil {
+		return renderer.RenderEscapeMap(ctx)
+	} else {
+		return nil, err
+	}
+}
+
+// renderEscapes records the escape values of a render into a new escape map
+func (facade *drawFacade) renderEscapes(ctx context.Context, render func(context.Context) (*image.NRGBA, error)) (*EscapeMap, error) {
+	req := facade.desc.UserRequest
+	subs := base.SubPixels(req.PixelSamples)
+	facade.escapes = draw.NewEscapeMap(facade.picture.Bounds(), len(subs))
+	defer func() {
+		facade.escapes = nil
+	}()
+
+	_, err := render(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &EscapeMap{Info: *facade.desc, escapes: facade.escapes}, nil
+}
+
+// colour the picture drawn by render.  Histogram colouring needs every escape value before it
+// can colour any pixel, so the escape values are recorded first and coloured afterwards.
+func (facade *drawFacade) colour(ctx context.Context, render func(context.Context) (*image.NRGBA, error)) (*image.NRGBA, error) {
+	if !facade.desc.UserRequest.Histogram {
+		return render(ctx)
+	}
+
+	em, err := facade.renderEscapes(ctx, render)
+	if err != nil {
+		return nil, err
+	}
+	em.escapes.Draw(escapePalette(facade.desc, em.escapes), facade.picture)
+	return facade.picture, nil
+}
+
+// The escape map format begins with a magic number and version, followed by the length of the
+// Info as JSON, the Info itself, the bounds of the picture and the number of samples of each
+// pixel.  Escape values follow, sample by sample and pixel by pixel in rows from the top
+// left.  All numbers are little endian.
+var escapeMagic = [8]byte{'G', 'O', 'D', 'E', 'L', 'E', 'S', 'C'}
+
+const escapeVersion = 1
+
+// Encoded size of an escape value
+const escapeSize = 41
+
+// Most escape values read from an escape map
+const maxEscapeValues = 1 << 30
+
+// Room made for escape values before any are read.  The values are stored as they are read, so
+// that a corrupt header cannot claim more memory than the input supplies.
+const escapeChunk = 1 << 16
+
+type escapeHeader struct {
+	Magic   [8]byte
+	Version uint32
+	InfoLen uint32
+}
+
+type escapeBounds struct {
+	MinX, MinY, MaxX, MaxY int32
+	Samples                uint32
+}
+
+// WriteEscapeMap writes the escape map in a versioned binary format
+func WriteEscapeMap(w io.Writer, em *EscapeMap) error {
+	info := &bytes.Buffer{}
+	ierr := WriteInfo(info, &em.Info)
+	if ierr != nil {
+		return ierr
+	}
+
+	bw := bufio.NewWriter(w)
+	header := escapeHeader{
+		Magic:   escapeMagic,
+		Version: escapeVersion,
+		InfoLen: uint32(info.Len()),
+	}
+	rect := em.escapes.Rect
+	bounds := escapeBounds{
+		MinX:    int32(rect.Min.X),
+		MinY:    int32(rect.Min.Y),
+		MaxX:    int32(rect.Max.X),
+		MaxY:    int32(rect.Max.Y),
+		Samples: uint32(em.escapes.Samples),
+	}
+
+	err := binary.Write(bw, binary.LittleEndian, header)
+	if err == nil {
+		_, err = bw.Write(info.Bytes())
+	}
+	if err == nil {
+		err = binary.Write(bw, binary.LittleEndian, bounds)
+	}
+
+	buf := make([]byte, escapeSize)
+	for _, ev := range em.escapes.Values {
+		if err != nil {
+			return err
+		}
+		encodeEscape(buf, ev)
+		_, err = bw.Write(buf)
+	}
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ReadEscapeMap reads an escape map written by WriteEscapeMap
+func ReadEscapeMap(r io.Reader) (*EscapeMap, error) {
+	br := bufio.NewReader(r)
+	header := escapeHeader{}
+	err := binary.Read(br, binary.LittleEndian, &header)
+	if err != nil {
+		return nil, err
+	}
+	if header.Magic != escapeMagic {
+		return nil, fmt.Errorf("Not an escape map")
+	}
+	if header.Version != escapeVersion {
+		return nil, fmt.Errorf("Unsupported escape map version: %v", header.Version)
+	}
+
+	text, terr := ioutil.ReadAll(io.LimitReader(br, int64(header.InfoLen)))
+	if terr != nil {
+		return nil, terr
+	}
+	if len(text) != int(header.InfoLen) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	info, ierr := ReadInfo(bytes.NewReader(text))
+	if ierr != nil {
+		return nil, ierr
+	}
+
+	bounds := escapeBounds{}
+	err = binary.Read(br, binary.LittleEndian, &bounds)
+	if err != nil {
+		return nil, err
+	}
+	rect := image.Rect(int(bounds.MinX), int(bounds.MinY), int(bounds.MaxX), int(bounds.MaxY))
+	if rect.Empty() || bounds.Samples == 0 {
+		return nil, fmt.Errorf("Escape map is empty")
+	}
+	// The dimensions of a rectangle that is not empty are positive
+	width, height, samples := int64(rect.Dx()), int64(rect.Dy()), int64(bounds.Samples)
+	if width > maxEscapeValues/height || width*height > maxEscapeValues/samples {
+		return nil, fmt.Errorf("Escape map is too large: %v with %v samples", rect, samples)
+	}
+	count := int(width * height * samples)
+
+	room := count
+	if room > escapeChunk {
+		room = escapeChunk
+	}
+	values := make([]base.EscapeValue, 0, room)
+	buf := make([]byte, escapeSize)
+	for len(values) < count {
+		_, err = io.ReadFull(br, buf)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, decodeEscape(buf))
+	}
+
+	escapes := &draw.EscapeMap{Rect: rect, Samples: int(samples), Values: values}
+	return &EscapeMap{Info: *info, escapes: escapes}, nil
+}
+
+func encodeEscape(buf []byte, ev base.EscapeValue) {
+	le := binary.LittleEndian
+	le.PutUint32(buf[0:], ev.InvDiv)
+	le.PutUint32(buf[4:], ev.Period)
+	buf[8] = 0
+	if ev.InSet {
+		buf[8] = 1
+	}
+	le.PutUint64(buf[9:], math.Float64bits(ev.Smooth))
+	le.PutUint64(buf[17:], math.Float64bits(ev.Distance))
+	le.PutUint64(buf[25:], math.Float64bits(real(ev.Normal)))
+	le.PutUint64(buf[33:], math.Float64bits(imag(ev.Normal)))
+}
+
+func decodeEscape(buf []byte) base.EscapeValue {
+	le := binary.LittleEndian
+	float := func(at int) float64 {
+		return math.Float64frombits(le.Uint64(buf[at:]))
+	}
+	return base.EscapeValue{
+		InvDiv:   le.Uint32(buf[0:]),
+		Period:   le.Uint32(buf[4:]),
+		InSet:    buf[8] != 0,
+		Smooth:   float(9),
+		Distance: float(17),
+		Normal:   complex(float(25), float(33)),
+	}
+}
@@ -0,0 +1,60 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+)
+
+type fixedCoords struct {
+	userMin   fixbase.FixedComplex
+	userMax   fixbase.FixedComplex
+	juliaSeed fixbase.FixedComplex
+	scale     uint
+}
+
+var _ fixbase.FixedCoordProvider = (*fixedCoords)(nil)
+
+// The fixed-point scale is the same as the big.Float precision
+func makeFixedCoords(desc *Info) *fixedCoords {
+	scale := desc.Precision
+	coords := &fixedCoords{}
+	coords.scale = scale
+	coords.userMin = fixbase.FixedComplex{
+		R: fixbase.FromBig(&desc.RealMin, scale),
+		I: fixbase.FromBig(&desc.ImagMin, scale),
+	}
+	coords.userMax = fixbase.FixedComplex{
+		R: fixbase.FromBig(&desc.RealMax, scale),
+		I: fixbase.FromBig(&desc.ImagMax, scale),
+	}
+	coords.juliaSeed = fixbase.FixedComplex{
+		R: fixbase.FromBig(&desc.JuliaReal, scale),
+		I: fixbase.FromBig(&desc.JuliaImag, scale),
+	}
+	return coords
+}
+
+func (coords *fixedCoords) Scale() uint {
+	return coords.scale
+}
+
+func (coords *fixedCoords) FixedUserCoords() (*fixbase.FixedComplex, *fixbase.FixedComplex) {
+	return &coords.userMin, &coords.userMax
+}
+
+func (coords *fixedCoords) FixedJuliaSeed() *fixbase.FixedComplex {
+	return &coords.juliaSeed
+}
+
+type fixedBaseFacade struct {
+	*baseFacade
+	*fixedCoords
+}
+
+var _ fixbase.RenderApplication = (*fixedBaseFacade)(nil)
+
+func makeFixedBaseFacade(desc *Info, baseApp *baseFacade) *fixedBaseFacade {
+	app := &fixedBaseFacade{}
+	app.baseFacade = baseApp
+	app.fixedCoords = makeFixedCoords(desc)
+	return app
+}
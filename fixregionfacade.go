@@ -0,0 +1,21 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/fixregion"
+)
+
+type fixedRegionFacade struct {
+	*baseFacade
+	*regionProvider
+	*fixedCoords
+}
+
+var _ fixregion.RenderApplication = (*fixedRegionFacade)(nil)
+
+func makeFixedRegionFacade(desc *Info, baseApp *baseFacade, region *regionProvider) *fixedRegionFacade {
+	facade := &fixedRegionFacade{}
+	facade.baseFacade = baseApp
+	facade.regionProvider = region
+	facade.fixedCoords = makeFixedCoords(desc)
+	return facade
+}
@@ -0,0 +1,68 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"log"
+	"sort"
+)
+
+// formula pairs the implementations of an escape-time formula for each numerics system that
+// supports it.
+type formula struct {
+	native nativebase.Formula
+	big    bigbase.Formula
+}
+
+// Available formulae, by code
+var formulas = map[string]formula{
+	"mandelbrot": {
+		native: nativebase.MandelbrotFormula{},
+		big:    bigbase.MandelbrotFormula{},
+	},
+	"burningship": {
+		native: nativebase.BurningShipFormula{},
+		big:    bigbase.BurningShipFormula{},
+	},
+	"tricorn": {
+		native: nativebase.TricornFormula{},
+		big:    bigbase.TricornFormula{},
+	},
+	"mandelbar": {
+		native: nativebase.TricornFormula{},
+		big:    bigbase.TricornFormula{},
+	},
+	"celtic": {
+		native: nativebase.CelticFormula{},
+		big:    bigbase.CelticFormula{},
+	},
+}
+
+// lookupFormula finds the formula for code, where the empty string means the Mandelbrot set.
+func lookupFormula(code string) (formula, bool) {
+	if code == "" {
+		code = DefaultFormulaCode
+	}
+	f, ok := formulas[code]
+	return f, ok
+}
+
+// findFormula finds the formula for an Info, which must already be validated
+func findFormula(desc *Info) formula {
+	code := desc.UserRequest.FormulaCode
+	f, ok := lookupFormula(code)
+	if !ok {
+		log.Panic("Unknown formula code:", code)
+	}
+	return f
+}
+
+// FormulaCodes lists the codes of the available formulae
+func FormulaCodes() []string {
+	codes := make([]string, 0, len(formulas))
+	for code := range formulas {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
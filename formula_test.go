@@ -0,0 +1,84 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/config"
+	"image"
+	"testing"
+)
+
+func TestConfigureFormula(t *testing.T) {
+	for _, code := range FormulaCodes() {
+		req := DefaultRequest()
+		req.FormulaCode = code
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Error("Unexpected error configuring formula", code, ":", err)
+			continue
+		}
+
+		if desc.NumericsStrategy != config.NativeNumericsMode {
+			t.Error("Expected native numerics for formula", code,
+				"but received", desc.NumericsStrategy)
+		}
+	}
+
+	req := DefaultRequest()
+	req.FormulaCode = "nonsense"
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for unknown formula code")
+	}
+
+	req = DefaultRequest()
+	req.FormulaCode = "burningship"
+	req.Numerics = config.DoubleDoubleNumericsMode
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for Burning Ship with double-double numerics")
+	}
+
+	req = DefaultRequest()
+	req.FormulaCode = "burningship"
+	req.Numerics = config.PerturbationNumericsMode
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for Burning Ship with perturbation numerics")
+	}
+}
+
+func TestRegionRenderFormula(t *testing.T) {
+	const width = 100
+	const height = 100
+
+	for _, code := range FormulaCodes() {
+		pictures := make([]image.Image, 2)
+		for i, mode := range []config.RenderMode{config.SequenceRenderMode, config.RegionRenderMode} {
+			req := DefaultRequest()
+			req.FormulaCode = code
+			req.Renderer = mode
+			req.ImageWidth = width
+			req.ImageHeight = height
+			req.RealMin = "-2.5"
+			req.RealMax = "1.5"
+			req.ImagMin = "-2"
+			req.ImagMax = "2"
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pictures[i], err = Render(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		bounds := pictures[0].Bounds()
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				if pictures[0].At(x, y) != pictures[1].At(x, y) {
+					t.Fatal("Region render of formula", code, "differed from sequence render at", x, y)
+				}
+			}
+		}
+	}
+}
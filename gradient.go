@@ -0,0 +1,203 @@
+package godelbrot
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/johnny-morrice/godelbrot/config"
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReadGradient reads a gradient described in JSON
+func ReadGradient(r io.Reader) (*config.Gradient, error) {
+	grad := &config.Gradient{}
+	dec := json.NewDecoder(r)
+	err := dec.Decode(grad)
+	if err != nil {
+		return nil, err
+	}
+
+	_, verr := makeGradient(grad)
+	if verr != nil {
+		return nil, verr
+	}
+	return grad, nil
+}
+
+// ReadGIMPGradient reads a gradient in the GIMP .ggr format.  Each segment is blended in RGB
+// through its midpoint, as GIMP blends linear RGB segments.  Segments that GIMP blends by
+// other functions, or in HSV, are approximated.
+func ReadGIMPGradient(r io.Reader) (*config.Gradient, error) {
+	sc := bufio.NewScanner(r)
+	lines := []string{}
+	for sc.Scan() {
+		lines = append(lines, strings.TrimSpace(sc.Text()))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 || lines[0] != "GIMP Gradient" {
+		return nil, fmt.Errorf("Not a GIMP gradient")
+	}
+	lines = lines[1:]
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "Name:") {
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("GIMP gradient has no segment count")
+	}
+
+	count, cerr := strconv.Atoi(lines[0])
+	if cerr != nil {
+		return nil, fmt.Errorf("Invalid GIMP gradient segment count: %v", cerr)
+	}
+	if count < 1 || count > len(lines)-1 {
+		return nil, fmt.Errorf("GIMP gradient has %v segments, but lists %v", count, len(lines)-1)
+	}
+
+	grad := &config.Gradient{}
+	for _, line := range lines[1 : count+1] {
+		fields := strings.Fields(line)
+		if len(fields) < 11 {
+			return nil, fmt.Errorf("Invalid GIMP gradient segment: %v", line)
+		}
+		nums := make([]float64, 11)
+		for i := range nums {
+			num, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid GIMP gradient segment: %v", err)
+			}
+			nums[i] = num
+		}
+
+		left, middle, right := nums[0], nums[1], nums[2]
+		start, end := nums[3:7], nums[7:11]
+		halfway := make([]float64, 4)
+		for i := range halfway {
+			halfway[i] = (start[i] + end[i]) / 2
+		}
+		grad.Stops = append(grad.Stops,
+			config.GradientStop{Position: left, Color: formatColor(start)},
+			config.GradientStop{Position: middle, Color: formatColor(halfway)},
+			config.GradientStop{Position: right, Color: formatColor(end)})
+	}
+
+	_, verr := makeGradient(grad)
+	if verr != nil {
+		return nil, verr
+	}
+	return grad, nil
+}
+
+// gradientPalette creates the palette of the gradient in the request
+func gradientPalette(req *config.Request) (Palette, error) {
+	grad, err := makeGradient(req.Gradient)
+	if err != nil {
+		return nil, err
+	}
+	return draw.NewGradientPalette(grad, req.IterateLimit), nil
+}
+
+// makeGradient validates a user description of a gradient
+func makeGradient(user *config.Gradient) (draw.Gradient, error) {
+	grad := draw.Gradient{}
+	if user == nil {
+		return grad, fmt.Errorf("No gradient given")
+	}
+
+	switch user.Space {
+	case "", "rgb":
+		grad.Space = draw.RGBSpace
+	case "hsv":
+		grad.Space = draw.HSVSpace
+	case "lab":
+		grad.Space = draw.LabSpace
+	case "lch":
+		grad.Space = draw.LChSpace
+	default:
+		return grad, fmt.Errorf("Invalid gradient colour space: %v", user.Space)
+	}
+
+	switch user.Mode {
+	case "", "clamp":
+		grad.Mode = draw.ClampMode
+	case "repeat":
+		grad.Mode = draw.RepeatMode
+	case "mirror":
+		grad.Mode = draw.MirrorMode
+	default:
+		return grad, fmt.Errorf("Invalid gradient mode: %v", user.Mode)
+	}
+
+	if user.Repeats < 0 || math.IsNaN(user.Repeats) || math.IsInf(user.Repeats, 0) {
+		return grad, fmt.Errorf("Invalid gradient repeats: %v", user.Repeats)
+	}
+	grad.Repeats = user.Repeats
+
+	grad.InSet = color.NRGBA{A: 255}
+	if user.InSet != "" {
+		inset, err := parseColor(user.InSet)
+		if err != nil {
+			return grad, err
+		}
+		grad.InSet = inset
+	}
+
+	if len(user.Stops) == 0 {
+		return grad, fmt.Errorf("Gradient has no stops")
+	}
+	grad.Stops = make([]draw.GradientStop, len(user.Stops))
+	for i, stop := range user.Stops {
+		if !(stop.Position >= 0 && stop.Position <= 1) {
+			return grad, fmt.Errorf("Gradient stop position %v outside [0, 1]", stop.Position)
+		}
+		col, err := parseColor(stop.Color)
+		if err != nil {
+			return grad, err
+		}
+		grad.Stops[i] = draw.GradientStop{Position: stop.Position, Color: col}
+	}
+	sort.SliceStable(grad.Stops, func(i, j int) bool {
+		return grad.Stops[i].Position < grad.Stops[j].Position
+	})
+
+	return grad, nil
+}
+
+// parseColor parses a colour written #rrggbb or #rrggbbaa
+func parseColor(text string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(text, "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 || !strings.HasPrefix(text, "#") {
+		return color.NRGBA{}, fmt.Errorf("Invalid colour %v: expected #rrggbb or #rrggbbaa", text)
+	}
+
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("Invalid colour %v: %v", text, err)
+	}
+	return color.NRGBA{
+		R: uint8(rgba >> 24),
+		G: uint8(rgba >> 16),
+		B: uint8(rgba >> 8),
+		A: uint8(rgba),
+	}, nil
+}
+
+// formatColor writes components in [0, 1] as #rrggbbaa
+func formatColor(rgba []float64) string {
+	text := "#"
+	for _, c := range rgba {
+		text += fmt.Sprintf("%02x", uint8(math.Floor((math.Max(0, math.Min(1, c))*255)+0.5)))
+	}
+	return text
+}
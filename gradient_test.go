@@ -0,0 +1,129 @@
+package godelbrot
+
+import (
+	"bytes"
+	"github.com/johnny-morrice/godelbrot/config"
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testGradient = `{
+	"Space": "lch",
+	"Mode": "mirror",
+	"Repeats": 3,
+	"InSet": "#00000000",
+	"Stops": [
+		{"Position": 1, "Color": "#ffcc00"},
+		{"Position": 0, "Color": "#000764"}
+	]
+}`
+
+func TestReadGradient(t *testing.T) {
+	grad, err := ReadGradient(strings.NewReader(testGradient))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	internal, err := makeGradient(grad)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if internal.Stops[0].Color != (color.NRGBA{0, 7, 100, 255}) || internal.Stops[1].Position != 1 {
+		t.Error("Expected stops in order of position but received", internal.Stops)
+	}
+
+	if internal.InSet != (color.NRGBA{}) {
+		t.Error("Expected transparent in set colour but received", internal.InSet)
+	}
+
+	bad := []string{
+		`{"Stops": []}`,
+		`{"Space": "cmyk", "Stops": [{"Position": 0, "Color": "#000000"}]}`,
+		`{"Mode": "bounce", "Stops": [{"Position": 0, "Color": "#000000"}]}`,
+		`{"Stops": [{"Position": 2, "Color": "#000000"}]}`,
+		`{"Stops": [{"Position": 0, "Color": "000000"}]}`,
+		`{"Stops": [{"Position": 0, "Color": "#00000g"}]}`,
+	}
+	for _, text := range bad {
+		if _, err := ReadGradient(strings.NewReader(text)); err == nil {
+			t.Error("Expected error for gradient", text)
+		}
+	}
+}
+
+func TestReadGIMPGradient(t *testing.T) {
+	ggr := `GIMP Gradient
+Name: Test
+2
+0.000000 0.250000 0.500000 0.000000 0.000000 0.000000 1.000000 1.000000 1.000000 1.000000 1.000000 0 0
+0.500000 0.750000 1.000000 1.000000 0.000000 0.000000 1.000000 0.000000 0.000000 1.000000 0.000000 0 0
+`
+	grad, err := ReadGIMPGradient(strings.NewReader(ggr))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []config.GradientStop{
+		{Position: 0, Color: "#000000ff"},
+		{Position: 0.25, Color: "#808080ff"},
+		{Position: 0.5, Color: "#ffffffff"},
+		{Position: 0.5, Color: "#ff0000ff"},
+		{Position: 0.75, Color: "#80008080"},
+		{Position: 1, Color: "#0000ff00"},
+	}
+	if !reflect.DeepEqual(grad.Stops, expect) {
+		t.Error("Expected stops", expect, "but received", grad.Stops)
+	}
+
+	if _, err := ReadGIMPGradient(strings.NewReader("GIMP Gradient\n3\n")); err == nil {
+		t.Error("Expected error for missing segments")
+	}
+}
+
+func TestRenderGradient(t *testing.T) {
+	grad, err := ReadGradient(strings.NewReader(testGradient))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := DefaultRequest()
+	req.ImageWidth = 40
+	req.ImageHeight = 30
+	req.PaletteCode = "gradient"
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for gradient palette without a gradient")
+	}
+
+	req.Gradient = grad
+	req.Smooth = true
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The gradient travels with the Info
+	buff := &bytes.Buffer{}
+	if err := WriteInfo(buff, desc); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadInfo(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read.UserRequest.Gradient, grad) {
+		t.Error("Expected gradient", grad, "but received", read.UserRequest.Gradient)
+	}
+
+	picture, err := Render(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The corner lies far outside the set, at the start of the gradient
+	if corner := picture.NRGBAAt(0, 0); corner.B < corner.R {
+		t.Error("Expected corner coloured as start of gradient but received", corner)
+	}
+}
@@ -8,10 +8,20 @@ import (
 type BaseNumerics struct {
 	WholeWidth  uint
 	WholeHeight uint
-	PicXMin     int
-	PicXMax     int // exclusive maximum
-	PicYMin     int
-	PicYMax     int // exclusive maximum
+	// Part of the whole picture that is rendered.  The empty rectangle means the whole picture.
+	Tile    image.Rectangle
+	PicXMin int
+	PicXMax int // exclusive maximum
+	PicYMin int
+	PicYMax int // exclusive maximum
+	// Pixels sampled within the picture bounds
+	Lattice Lattice
+	// Moves the sample point of each pixel within its cell of the sample grid
+	Jitter Jitter
+	// Closed when the render is cancelled
+	Done <-chan struct{}
+	// Counts the pixels computed
+	Tracker *Tracker
 }
 
 func Make(app RenderApplication) BaseNumerics {
@@ -19,6 +29,10 @@ func Make(app RenderApplication) BaseNumerics {
 	base := BaseNumerics{}
 	base.WholeWidth = w
 	base.WholeHeight = h
+	config := app.BaseConfig()
+	base.Tile = config.Tile
+	base.Done = config.Done
+	base.Tracker = config.Tracker
 	base.RestorePicBounds()
 	return base
 }
@@ -31,19 +45,17 @@ func (base *BaseNumerics) PictureMax() (int, int) {
 	return base.PicXMax, base.PicYMax
 }
 
+// Restore the drawing context to the tile, or to the whole image when there is no tile
 func (base *BaseNumerics) RestorePicBounds() {
-	base.setWidth(base.WholeWidth)
-	base.setHeight(base.WholeHeight)
+	base.PictureSubImage(base.TileRect())
 }
 
-func (base *BaseNumerics) setWidth(width uint) {
-	base.PicXMax = int(width)
-	base.PicXMin = 0
-}
-
-func (base *BaseNumerics) setHeight(height uint) {
-	base.PicYMax = int(height)
-	base.PicYMin = 0
+// TileRect returns the part of the picture that is rendered
+func (base *BaseNumerics) TileRect() image.Rectangle {
+	if base.Tile.Empty() {
+		return image.Rect(0, 0, int(base.WholeWidth), int(base.WholeHeight))
+	}
+	return base.Tile
 }
 
 // Change the drawing context to a sub-part of the image
@@ -53,3 +65,18 @@ func (base *BaseNumerics) PictureSubImage(rect image.Rectangle) {
 	base.PicXMax = rect.Max.X
 	base.PicYMax = rect.Max.Y
 }
+
+// Sample only the pixels on the lattice
+func (base *BaseNumerics) PictureLattice(lattice Lattice) {
+	base.Lattice = lattice
+}
+
+// Jitter the sample point of each pixel
+func (base *BaseNumerics) PictureJitter(jitter Jitter) {
+	base.Jitter = jitter
+}
+
+// Cancelled returns true when the render has been cancelled
+func (base *BaseNumerics) Cancelled() bool {
+	return Cancelled(base.Done)
+}
@@ -1,6 +1,7 @@
 package base
 
 import (
+	"image"
 	"testing"
 )
 
@@ -25,3 +26,37 @@ func TestCreateBaseNumerics(t *testing.T) {
 		t.Error("numerics had unexpected value", numerics)
 	}
 }
+
+func TestCreateBaseNumericsTile(t *testing.T) {
+	mock := &MockRenderApplication{}
+	mock.PictureWidth = 10
+	mock.PictureHeight = 20
+	mock.Base.Tile = image.Rect(2, 4, 6, 8)
+
+	numerics := Make(mock)
+
+	if !(mock.TPictureDimensions && mock.TBaseConfig) {
+		t.Error("Expected method not called on mock", mock)
+	}
+
+	expect := BaseNumerics{
+		WholeWidth:  10,
+		WholeHeight: 20,
+		Tile:        mock.Base.Tile,
+		PicXMin:     2,
+		PicXMax:     6,
+		PicYMin:     4,
+		PicYMax:     8,
+	}
+
+	if numerics != expect {
+		t.Error("Expected", expect, "but received", numerics)
+	}
+
+	numerics.PictureSubImage(image.Rect(3, 5, 4, 6))
+	numerics.RestorePicBounds()
+
+	if numerics != expect {
+		t.Error("Expected", expect, "after restore but received", numerics)
+	}
+}
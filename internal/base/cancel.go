@@ -0,0 +1,11 @@
+package base
+
+// Cancelled returns true when done has been closed.  A nil channel is never closed.
+func Cancelled(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
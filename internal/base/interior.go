@@ -0,0 +1,93 @@
+package base
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// PeriodTolerance is how close an orbit must return to its start to be considered periodic
+const PeriodTolerance = 1e-9
+
+// Points that reach the iterate limit may still escape while their period is sought
+const periodEscape = 1e6
+
+// Number of Newton steps taken to find a point on an attracting cycle
+const newtonSteps = 16
+
+// Period returns the period of the cycle that the orbit of z has converged to, or zero if the
+// orbit does not return to z within limit iterations, or escapes.
+func Period(z complex128, step func(complex128) complex128, limit uint32) uint32 {
+	tolerance := PeriodTolerance * math.Max(1, cmplx.Abs(z))
+	w := z
+	for p := uint32(1); p <= limit; p++ {
+		w = step(w)
+		if cmplx.Abs(w-z) < tolerance {
+			return p
+		}
+		if cmplx.Abs(w) > periodEscape {
+			return 0
+		}
+	}
+	return 0
+}
+
+// BulbCycle returns a point on the attracting cycle of z^2 + c, and its period, where c lies
+// in the main cardioid or the period-2 bulb of the Mandelbrot set.
+func BulbCycle(c complex128) (complex128, uint32) {
+	// The fixed point attracts when its multiplier 2z lies within the unit circle
+	z := (1 - cmplx.Sqrt(1-(4*c))) / 2
+	if cmplx.Abs(2*z) <= 1 {
+		return z, 1
+	}
+	return (cmplx.Sqrt(-3-(4*c)) - 1) / 2, 2
+}
+
+// InteriorEstimate sets the period and interior distance estimate of a member c of the
+// Mandelbrot set, given a point z close to its attracting cycle.  The distance is only
+// valid for z^2 + c.
+func (ev *EscapeValue) InteriorEstimate(z, c complex128, period uint32, pixelSize float64) {
+	ev.Period = period
+	if period == 0 || pixelSize == 0 {
+		return
+	}
+
+	// Newton's method moves z on to the cycle, solving f^p(z) = z
+	for k := 0; k < newtonSteps; k++ {
+		w := z
+		var dw complex128 = 1
+		for p := uint32(0); p < period; p++ {
+			dw = 2 * w * dw
+			w = (w * w) + c
+		}
+		if dw == 1 {
+			break
+		}
+		next := z - ((w - z) / (dw - 1))
+		if next == z || cmplx.IsNaN(next) {
+			break
+		}
+		z = next
+	}
+
+	// Derivatives of f^p by z and c, and their second derivatives by z
+	var dz complex128 = 1
+	var dc, dzdz, dcdz complex128
+	w := z
+	for p := uint32(0); p < period; p++ {
+		dcdz = 2 * ((w * dcdz) + (dc * dz))
+		dc = (2 * w * dc) + 1
+		dzdz = 2 * ((dz * dz) + (w * dzdz))
+		dz = 2 * w * dz
+		w = (w * w) + c
+	}
+
+	multiplier := cmplx.Abs(dz)
+	if multiplier >= 1 {
+		return
+	}
+	denom := cmplx.Abs(dcdz + ((dzdz * dc) / (1 - dz)))
+	if denom == 0 {
+		return
+	}
+	ev.Distance = (1 - (multiplier * multiplier)) / (denom * pixelSize)
+}
@@ -0,0 +1,71 @@
+package base
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func TestPeriod(t *testing.T) {
+	// Under z^2 - 1, zero lies on a cycle of period 2
+	step := func(z complex128) complex128 {
+		return (z * z) - 1
+	}
+	if actual := Period(0, step, 100); actual != 2 {
+		t.Error("Expected period 2 but received", actual)
+	}
+
+	escape := func(z complex128) complex128 {
+		return (z * z) + 1
+	}
+	if actual := Period(0, escape, 100); actual != 0 {
+		t.Error("Expected escaping orbit to have no period but received", actual)
+	}
+}
+
+func TestBulbCycle(t *testing.T) {
+	points := map[complex128]uint32{
+		0:            1,
+		0.1 + 0.2i:   1,
+		-1:           2,
+		-1.1 + 0.1i:  2,
+		-0.7 + 0.05i: 1,
+	}
+
+	for c, expect := range points {
+		z, period := BulbCycle(c)
+		if period != expect {
+			t.Error("Expected period", expect, "at", c, "but received", period)
+			continue
+		}
+
+		w := z
+		for p := uint32(0); p < period; p++ {
+			w = (w * w) + c
+		}
+		if cmplx.Abs(w-z) > 0.000001 {
+			t.Error("Expected", z, "to lie on a cycle at", c, "but it moved to", w)
+		}
+	}
+}
+
+func TestInteriorEstimate(t *testing.T) {
+	ev := EscapeValue{}
+	ev.InteriorEstimate(0, 0, 1, 0.25)
+
+	if ev.Period != 1 {
+		t.Error("Expected period 1 but received", ev.Period)
+	}
+
+	// The estimate at the centre of the main cardioid is 1/2
+	expect := 2.0
+	if actual := ev.Distance; actual-expect > 0.000001 || expect-actual > 0.000001 {
+		t.Error("Expected distance", expect, "but was", actual)
+	}
+
+	// Newton's method finds the cycle from a nearby point
+	near := EscapeValue{}
+	near.InteriorEstimate(-0.05+0.01i, -1, 2, 1)
+	if near.Distance <= 0 {
+		t.Error("Expected positive distance but was", near.Distance)
+	}
+}
@@ -0,0 +1,65 @@
+package base
+
+import (
+	"image"
+)
+
+// Lattice selects the pixels sampled by one pass of a progressive render.  The zero Lattice
+// contains every pixel.
+type Lattice struct {
+	// Pixel from which the lattice is measured
+	Origin image.Point
+	// Distance between sampled pixels.  Zero means 1.
+	Step int
+	// Distance between the pixels of the previous pass, which are skipped.  Zero means none.
+	Skip int
+}
+
+// Contains returns true when the lattice samples pixel (i, j)
+func (l Lattice) Contains(i, j int) bool {
+	x := i - l.Origin.X
+	y := j - l.Origin.Y
+	if l.Step > 1 && (x%l.Step != 0 || y%l.Step != 0) {
+		return false
+	}
+	if l.Skip > 0 && x%l.Skip == 0 && y%l.Skip == 0 {
+		return false
+	}
+	return true
+}
+
+// Block returns the pixels drawn for sample (i, j), which lie between it and the next sample
+func (l Lattice) Block(i, j int) image.Rectangle {
+	step := l.Step
+	if step < 1 {
+		step = 1
+	}
+	return image.Rect(i, j, i+step, j+step)
+}
+
+// Count returns the number of pixels on the lattice within the rectangle
+func (l Lattice) Count(rect image.Rectangle) int {
+	count := 0
+	for i := rect.Min.X; i < rect.Max.X; i++ {
+		for j := rect.Min.Y; j < rect.Max.Y; j++ {
+			if l.Contains(i, j) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// ProgressivePasses returns lattices for a progressive render of the picture, from 1/8 of the
+// full resolution up to full resolution.  Each pass skips the pixels sampled before it.
+func ProgressivePasses(origin image.Point) []Lattice {
+	steps := []int{8, 4, 2, 1}
+	passes := make([]Lattice, len(steps))
+	for i, step := range steps {
+		passes[i] = Lattice{Origin: origin, Step: step}
+		if i > 0 {
+			passes[i].Skip = steps[i-1]
+		}
+	}
+	return passes
+}
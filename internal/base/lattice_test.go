@@ -0,0 +1,65 @@
+package base
+
+import (
+	"image"
+	"testing"
+)
+
+func TestLatticeContains(t *testing.T) {
+	lattice := Lattice{Origin: image.Pt(1, 2), Step: 2, Skip: 4}
+
+	expect := map[image.Point]bool{
+		image.Pt(1, 2): false,
+		image.Pt(3, 2): true,
+		image.Pt(1, 4): true,
+		image.Pt(3, 4): true,
+		image.Pt(2, 2): false,
+		image.Pt(5, 6): false,
+	}
+
+	for pt, contains := range expect {
+		if lattice.Contains(pt.X, pt.Y) != contains {
+			t.Error("Expected Contains", contains, "for", pt)
+		}
+	}
+
+	if !(Lattice{}).Contains(7, 11) {
+		t.Error("Expected zero lattice to contain every pixel")
+	}
+}
+
+func TestLatticeBlock(t *testing.T) {
+	lattice := Lattice{Step: 4}
+	expect := image.Rect(4, 8, 8, 12)
+	if actual := lattice.Block(4, 8); actual != expect {
+		t.Error("Expected block", expect, "but received", actual)
+	}
+
+	if actual := (Lattice{}).Block(4, 8); actual != image.Rect(4, 8, 5, 9) {
+		t.Error("Expected single pixel block but received", actual)
+	}
+}
+
+func TestProgressivePasses(t *testing.T) {
+	bounds := image.Rect(3, 5, 40, 30)
+	passes := ProgressivePasses(bounds.Min)
+
+	if last := passes[len(passes)-1]; last.Step != 1 {
+		t.Error("Expected last pass at full resolution, but received", last)
+	}
+
+	// Each pixel should be sampled by exactly one pass
+	for i := bounds.Min.X; i < bounds.Max.X; i++ {
+		for j := bounds.Min.Y; j < bounds.Max.Y; j++ {
+			count := 0
+			for _, lattice := range passes {
+				if lattice.Contains(i, j) {
+					count++
+				}
+			}
+			if count != 1 {
+				t.Fatal("Pixel", i, j, "sampled by", count, "passes")
+			}
+		}
+	}
+}
@@ -1,8 +1,53 @@
 package base
 
+import (
+	"math"
+	"math/cmplx"
+)
+
 type EscapeValue struct {
-	InvDiv uint8
+	InvDiv uint32
 	InSet  bool
+	// Continuous escape value (normalized iteration count)
+	Smooth float64
+	// Estimated distance to the boundary of the set, in pixels.  Only computed for escaping
+	// points when the numerics track the derivative of the orbit, and for members when the
+	// numerics track the interior.
+	Distance float64
+	// Unit normal to the level curves of the escape value, used for lighting effects
+	Normal complex128
+	// Period of the attracting cycle of a member, or zero if unknown
+	Period uint32
+}
+
+// Estimate sets the exterior distance estimate and normal of an escaped point, given the final
+// value of z and its derivative dz.
+func (ev *EscapeValue) Estimate(z, dz complex128, pixelSize float64) {
+	zabs := cmplx.Abs(z)
+	dzabs := cmplx.Abs(dz)
+	if dzabs == 0 || pixelSize == 0 {
+		return
+	}
+
+	ev.Distance = (zabs * math.Log(zabs)) / (dzabs * pixelSize)
+
+	u := z / dz
+	uabs := cmplx.Abs(u)
+	if uabs > 0 {
+		ev.Normal = u / complex(uabs, 0)
+	}
+}
+
+// PowerDerivative returns the derivative of z^n, given the derivative dz of z.  Zero means 2.
+func PowerDerivative(z, dz complex128, n uint) complex128 {
+	if n < 2 {
+		n = 2
+	}
+	var zn complex128 = 1
+	for k := uint(1); k < n; k++ {
+		zn *= z
+	}
+	return complex(float64(n), 0) * zn * dz
 }
 
 // PixelMember is a EscapeValue associated with a pixel
@@ -11,3 +56,26 @@ type PixelMember struct {
 	J      int
 	Member EscapeValue
 }
+
+// Extra iterations taken after escape.  These shrink the error in the smooth escape value
+// that results from the low divergence limit.
+const SmoothIterations uint32 = 4
+
+// SmoothEscape returns the normalized iteration count, given the number of iterations taken
+// and the magnitude of z after that many iterations.
+func SmoothEscape(iterations uint32, zabs float64) float64 {
+	return MultibrotSmoothEscape(iterations, zabs, 2)
+}
+
+// MultibrotSmoothEscape returns the normalized iteration count for z^n + c
+func MultibrotSmoothEscape(iterations uint32, zabs float64, exponent uint) float64 {
+	logn := math.Ln2
+	if exponent > 2 {
+		logn = math.Log(float64(exponent))
+	}
+	mu := float64(iterations) + 1.0 - (math.Log(math.Log(zabs)) / logn)
+	if mu < 0 || math.IsNaN(mu) {
+		return 0
+	}
+	return mu
+}
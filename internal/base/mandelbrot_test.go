@@ -0,0 +1,64 @@
+package base
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSmoothEscape(t *testing.T) {
+	// |z| = e^2 means log2(log|z|) = 1
+	actual := SmoothEscape(10, 7.38905609893065)
+	expect := 10.0
+	if actual-expect > 0.000001 || expect-actual > 0.000001 {
+		t.Error("Expected smooth escape", expect, "but was", actual)
+	}
+
+	if SmoothEscape(0, 1e300) != 0 {
+		t.Error("Expected smooth escape to be clamped to zero")
+	}
+}
+
+func TestMultibrotSmoothEscape(t *testing.T) {
+	// |z| = e^3 means log3(log|z|) = 1
+	actual := MultibrotSmoothEscape(10, 20.085536923187668, 3)
+	expect := 10.0
+	if actual-expect > 0.000001 || expect-actual > 0.000001 {
+		t.Error("Expected smooth escape", expect, "but was", actual)
+	}
+}
+
+func TestPowerDerivative(t *testing.T) {
+	const z complex128 = 1 + 2i
+	const dz complex128 = 0.5i
+
+	expect := 3 * z * z * dz
+	if actual := PowerDerivative(z, dz, 3); actual != expect {
+		t.Error("Expected derivative", expect, "but was", actual)
+	}
+
+	expect = 2 * z * dz
+	if actual := PowerDerivative(z, dz, 0); actual != expect {
+		t.Error("Expected zero exponent to mean 2, giving", expect, "but was", actual)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	ev := EscapeValue{}
+	// |z| = e means |z| log|z| = e
+	ev.Estimate(complex(math.E, 0), 2i, 0.5)
+
+	expect := math.E
+	if actual := ev.Distance; actual-expect > 0.000001 || expect-actual > 0.000001 {
+		t.Error("Expected distance", expect, "but was", actual)
+	}
+
+	if ev.Normal != -1i {
+		t.Error("Expected normal", -1i, "but was", ev.Normal)
+	}
+
+	unset := EscapeValue{}
+	unset.Estimate(2, 0, 1)
+	if unset.Distance != 0 || unset.Normal != 0 {
+		t.Error("Expected zero derivative to leave estimate unset, but was", unset)
+	}
+}
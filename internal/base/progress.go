@@ -0,0 +1,115 @@
+package base
+
+import (
+	"sync"
+	"time"
+)
+
+// Phase is a stage of the render
+type Phase uint
+
+const (
+	// Regions are split until they are uniform or small
+	SubdividePhase = Phase(iota)
+	// Uniform regions are filled
+	UniformPhase
+	// Pixels are computed one by one
+	SequencePhase
+	// Orbits are sampled for the Buddhabrot, each counting as a pixel
+	SamplePhase
+)
+
+// Progress describes how far a render has got
+type Progress struct {
+	Phase Phase
+	// Pixels computed or drawn so far, counting each supersample
+	Pixels int
+	// Pixels computed or drawn by the whole render
+	Total int
+	// Regions waiting to be subdivided or drawn
+	Regions int
+}
+
+// ObserveInterval is the least time between calls to the observer of a Tracker, except at the
+// beginning of a phase and the end of the render.
+const ObserveInterval = 100 * time.Millisecond
+
+// Tracker counts the progress of a render, telling an observer of the changes.  It is safe for
+// concurrent use, and a nil Tracker counts nothing.
+type Tracker struct {
+	mutex    sync.Mutex
+	progress Progress
+	// Number of changes to the progress
+	changes uint64
+	// When the observer was last called
+	observed time.Time
+	interval time.Duration
+
+	// Held while calling the observer
+	observing sync.Mutex
+	// Number of changes the observer has been told of
+	told     uint64
+	observer func(Progress)
+}
+
+// NewTracker creates a tracker that calls observer with the progress at the beginning of each
+// phase, at the end of the render, and at most once per ObserveInterval between.  Calls to
+// observer are never concurrent, and never tell of older progress than the last.
+func NewTracker(observer func(Progress)) *Tracker {
+	return &Tracker{observer: observer, interval: ObserveInterval}
+}
+
+// Begin a phase of the render, which brings the total pixels to total, with the given number
+// of regions pending.
+func (t *Tracker) Begin(phase Phase, total int, regions int) {
+	t.update(true, func(p *Progress) {
+		p.Phase = phase
+		p.Total = total
+		p.Regions = regions
+	})
+}
+
+// AddPixels counts n more pixels complete
+func (t *Tracker) AddPixels(n int) {
+	t.update(false, func(p *Progress) {
+		p.Pixels += n
+	})
+}
+
+// AddRegions counts n more regions pending.  Negative n counts regions complete.
+func (t *Tracker) AddRegions(n int) {
+	t.update(false, func(p *Progress) {
+		p.Regions += n
+	})
+}
+
+// update changes the progress, then tells the observer if the change is urgent or enough time
+// has passed.  The observer is called without holding the lock on the progress, so that it
+// does not hold up the render.
+func (t *Tracker) update(urgent bool, f func(*Progress)) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	f(&t.progress)
+	t.changes++
+	progress, change := t.progress, t.changes
+	complete := progress.Pixels >= progress.Total && progress.Regions == 0
+	now := time.Now()
+	if !urgent && !complete && now.Sub(t.observed) < t.interval {
+		t.mutex.Unlock()
+		return
+	}
+	t.observed = now
+	t.mutex.Unlock()
+
+	t.observing.Lock()
+	defer t.observing.Unlock()
+	// A later change may have been observed while waiting
+	if change < t.told {
+		return
+	}
+	t.told = change
+	t.observer(progress)
+}
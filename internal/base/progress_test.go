@@ -0,0 +1,68 @@
+package base
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	seen := []Progress{}
+	tracker := NewTracker(func(p Progress) {
+		seen = append(seen, p)
+	})
+	tracker.interval = 0
+
+	tracker.Begin(SubdividePhase, 100, 1)
+	tracker.AddRegions(3)
+	tracker.Begin(UniformPhase, 120, 4)
+	tracker.AddPixels(20)
+	tracker.AddRegions(-1)
+
+	expect := Progress{Phase: UniformPhase, Pixels: 20, Total: 120, Regions: 3}
+
+	if len(seen) != 5 {
+		t.Fatal("Expected 5 observations but received", len(seen))
+	}
+
+	if actual := seen[len(seen)-1]; actual != expect {
+		t.Error("Expected", expect, "but received", actual)
+	}
+}
+
+func TestTrackerThrottled(t *testing.T) {
+	seen := []Progress{}
+	tracker := NewTracker(func(p Progress) {
+		seen = append(seen, p)
+	})
+	tracker.interval = time.Hour
+
+	tracker.Begin(SequencePhase, 100, 0)
+	for i := 0; i < 10; i++ {
+		tracker.AddPixels(5)
+	}
+	tracker.Begin(SequencePhase, 100, 0)
+	for i := 0; i < 10; i++ {
+		tracker.AddPixels(5)
+	}
+
+	expect := []Progress{
+		{Phase: SequencePhase, Total: 100},
+		{Phase: SequencePhase, Pixels: 50, Total: 100},
+		{Phase: SequencePhase, Pixels: 100, Total: 100},
+	}
+	if len(seen) != len(expect) {
+		t.Fatal("Expected", len(expect), "observations but received", seen)
+	}
+	for i, p := range expect {
+		if seen[i] != p {
+			t.Error("Expected", p, "but received", seen[i])
+		}
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	tracker.Begin(SequencePhase, 10, 0)
+	tracker.AddPixels(10)
+	tracker.AddRegions(-1)
+}
@@ -1,5 +1,9 @@
 package base
 
+import (
+	"image"
+)
+
 // A facade used by subsystems to interact with the application at large
 type RenderApplication interface {
 	// Basic configuration
@@ -8,6 +12,30 @@ type RenderApplication interface {
 }
 
 type BaseConfig struct {
-	IterateLimit uint8
+	IterateLimit uint32
 	DivergeLimit float64
+	// Render the Julia set, with each pixel giving the initial value of z
+	Julia bool
+	// Power of z in z^n + c.  Zero means 2.
+	Exponent uint
+	// Skip points inside the main cardioid and period-2 bulb of the Mandelbrot set
+	CardioidCheck bool
+	// Stop iterating points whose orbits are found to cycle
+	PeriodicityCheck bool
+	// Track the derivative of the orbit to estimate the distance of escaping points from the set
+	TrackDerivative bool
+	// Find the period and interior distance of points inside the set
+	TrackInterior bool
+	// Number of samples taken in each pixel, which must be a square number.  Zero means 1.
+	PixelSamples uint
+	// Jitter the samples within the grid, rather than placing them regularly
+	JitterSamples bool
+	// Number of render threads.  Zero means 1.
+	Jobs uint16
+	// Part of the picture to render, in pixels.  The empty rectangle means the whole picture.
+	Tile image.Rectangle
+	// Closed when the render is cancelled.  Nil means the render is never cancelled.
+	Done <-chan struct{}
+	// Counts the progress of the render.  Nil means progress is not counted.
+	Tracker *Tracker
 }
@@ -0,0 +1,86 @@
+package base
+
+import (
+	"math"
+)
+
+// SubPixel is the position of a sample relative to the usual sample point of its pixel, in
+// pixels.  Each coordinate lies in [-0.5, 0.5).
+type SubPixel struct {
+	X float64
+	Y float64
+}
+
+// SubPixels returns the positions sampled within each pixel, at the centres of the cells of a
+// square grid.
+func SubPixels(samples uint) []SubPixel {
+	side := SampleGridSide(samples)
+	if side <= 1 {
+		return []SubPixel{{}}
+	}
+
+	cell := 1.0 / float64(side)
+	subs := make([]SubPixel, 0, side*side)
+	for i := uint(0); i < side; i++ {
+		for j := uint(0); j < side; j++ {
+			subs = append(subs, SubPixel{
+				X: ((float64(i) + 0.5) * cell) - 0.5,
+				Y: ((float64(j) + 0.5) * cell) - 0.5,
+			})
+		}
+	}
+	return subs
+}
+
+// Jitter moves a sample to a random point within its cell of the sample grid.  The point
+// differs from pixel to pixel, so that no pattern repeats across the picture, but is the same
+// on every render.
+type Jitter struct {
+	// Width of a cell of the grid, in pixels.  Zero means no jitter.
+	Cell float64
+	// Index of the sample within each pixel
+	Sample int
+}
+
+// MakeJitter returns the jitter of a sample taken from a grid of the given number of samples
+func MakeJitter(samples uint, sample int) Jitter {
+	side := SampleGridSide(samples)
+	if side <= 1 {
+		return Jitter{}
+	}
+	return Jitter{Cell: 1.0 / float64(side), Sample: sample}
+}
+
+// At returns the offset of the sample in pixel (i, j) from the centre of its cell, in pixels
+func (jit Jitter) At(i, j int) (float64, float64) {
+	if jit.Cell == 0 {
+		return 0, 0
+	}
+
+	// Hash the pixel and sample with the splitmix64 finalizer
+	h := uint64(uint32(i)) | (uint64(uint32(j)) << 32)
+	h ^= uint64(jit.Sample+1) * 0x9e3779b97f4a7c15
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+
+	const unit = 1 << 32
+	dx := (float64(h>>32) / unit) - 0.5
+	dy := (float64(h&(unit-1)) / unit) - 0.5
+	return dx * jit.Cell, dy * jit.Cell
+}
+
+// SampleGridSide returns the width of the grid of samples in each pixel, or zero if the number
+// of samples is not a square number.
+func SampleGridSide(samples uint) uint {
+	if samples == 0 {
+		return 1
+	}
+	side := uint(math.Sqrt(float64(samples)) + 0.5)
+	if side*side != samples {
+		return 0
+	}
+	return side
+}
@@ -0,0 +1,74 @@
+package base
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSampleGridSide(t *testing.T) {
+	expect := map[uint]uint{
+		0:  1,
+		1:  1,
+		2:  0,
+		4:  2,
+		8:  0,
+		9:  3,
+		16: 4,
+	}
+	for samples, side := range expect {
+		if actual := SampleGridSide(samples); actual != side {
+			t.Error("Expected side", side, "for", samples, "samples, but received:", actual)
+		}
+	}
+}
+
+func TestSubPixels(t *testing.T) {
+	single := SubPixels(1)
+	if len(single) != 1 || single[0] != (SubPixel{}) {
+		t.Error("Expected the pixel centre alone for a single sample, but received:", single)
+	}
+
+	grid := SubPixels(4)
+	expect := []SubPixel{{-0.25, -0.25}, {-0.25, 0.25}, {0.25, -0.25}, {0.25, 0.25}}
+	if len(grid) != len(expect) {
+		t.Fatal("Expected", len(expect), "grid samples, but received:", len(grid))
+	}
+	for i, sub := range grid {
+		if sub != expect[i] {
+			t.Error("Expected grid sample", expect[i], "but received:", sub)
+		}
+	}
+}
+
+func TestJitter(t *testing.T) {
+	if jit := MakeJitter(1, 0); jit != (Jitter{}) {
+		t.Error("Expected no jitter for a single sample, but received:", jit)
+	}
+	if dx, dy := (Jitter{}).At(3, 4); dx != 0 || dy != 0 {
+		t.Error("Expected no offset without jitter, but received:", dx, dy)
+	}
+
+	const samples = 4
+	type offset struct{ dx, dy float64 }
+	seen := map[offset]bool{}
+	for s := 0; s < samples; s++ {
+		jit := MakeJitter(samples, s)
+		for i := 0; i < 8; i++ {
+			for j := 0; j < 8; j++ {
+				dx, dy := jit.At(i, j)
+				if math.Abs(dx) > 0.25 || math.Abs(dy) > 0.25 {
+					t.Error("Expected jitter within the cell, but received:", dx, dy)
+				}
+				if again, _ := jit.At(i, j); again != dx {
+					t.Error("Expected jitter to repeat, but received:", dx, again)
+				}
+				seen[offset{dx, dy}] = true
+			}
+		}
+	}
+
+	if len(seen) != samples*8*8 {
+		t.Error("Expected a different jitter for each sample of each pixel, but found",
+			len(seen), "offsets")
+	}
+}
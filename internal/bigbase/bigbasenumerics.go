@@ -17,12 +17,26 @@ type BigBaseNumerics struct {
 	ImagMax big.Float
 
 	SqrtDivergeLimit big.Float
-	IterateLimit     uint8
+	IterateLimit     uint32
 
 	Runit big.Float
 	Iunit big.Float
 
 	Precision uint
+
+	// Julia set constant, or nil for the Mandelbrot set
+	JuliaSeed *BigComplex
+	Exponent  uint
+	Formula   Formula
+
+	CardioidCheck    bool
+	PeriodicityCheck bool
+
+	TrackDerivative bool
+	TrackInterior   bool
+
+	// Top left of the whole picture, which SubImage leaves alone
+	corner BigComplex
 }
 
 func Make(app RenderApplication) BigBaseNumerics {
@@ -67,6 +81,23 @@ func Make(app RenderApplication) BigBaseNumerics {
 		Runit:     rUnit,
 		Iunit:     iUnit,
 		Precision: prec,
+		Exponent:  baseConfig.Exponent,
+		Formula:   app.BigFormula(),
+
+		corner: BigComplex{left, top},
+	}
+
+	quadratic := !baseConfig.Julia && baseConfig.Exponent <= 2 && isMandelbrot(bbn.Formula)
+	bbn.CardioidCheck = baseConfig.CardioidCheck && quadratic
+	bbn.PeriodicityCheck = baseConfig.PeriodicityCheck
+	bbn.TrackDerivative = baseConfig.TrackDerivative
+	bbn.TrackInterior = baseConfig.TrackInterior
+
+	if baseConfig.Julia {
+		seed := app.BigJuliaSeed()
+		bbn.JuliaSeed = &BigComplex{}
+		bbn.JuliaSeed.R.SetPrec(prec).Set(seed.Real())
+		bbn.JuliaSeed.I.SetPrec(prec).Set(seed.Imag())
 	}
 
 	return bbn
@@ -107,19 +138,53 @@ func (bbn *BigBaseNumerics) MakeMember(c *BigComplex) BigEscapeValue {
 		C:                c,
 		Prec:             bbn.Precision,
 		SqrtDivergeLimit: &bbn.SqrtDivergeLimit,
+		Seed:             bbn.JuliaSeed,
+		Exponent:         bbn.Exponent,
+		Formula:          bbn.Formula,
+		CardioidCheck:    bbn.CardioidCheck,
+		PeriodicityCheck: bbn.PeriodicityCheck,
+		TrackDerivative:  bbn.TrackDerivative,
+		PixelSize:        &bbn.Runit,
+		TrackInterior:    bbn.TrackInterior,
+		Done:             bbn.Done,
 	}
 }
 
+// Offset moves the sample point of every pixel by a fraction of a pixel
+func (bbn *BigBaseNumerics) Offset(dx, dy float64) {
+	r := bbn.MakeBigFloat(dx)
+	r.Mul(&r, &bbn.Runit)
+	i := bbn.MakeBigFloat(dy)
+	i.Mul(&i, &bbn.Iunit)
+
+	// Replace rather than Set, as copies of the numerics may share the old values
+	rmin, rmax := bbn.MakeBigFloat(0.0), bbn.MakeBigFloat(0.0)
+	imin, imax := bbn.MakeBigFloat(0.0), bbn.MakeBigFloat(0.0)
+	rmin.Add(&bbn.RealMin, &r)
+	rmax.Add(&bbn.RealMax, &r)
+	imin.Sub(&bbn.ImagMin, &i)
+	imax.Sub(&bbn.ImagMax, &i)
+	bbn.RealMin = rmin
+	bbn.RealMax = rmax
+	bbn.ImagMin = imin
+	bbn.ImagMax = imax
+
+	cr, ci := bbn.MakeBigFloat(0.0), bbn.MakeBigFloat(0.0)
+	cr.Add(&bbn.corner.R, &r)
+	ci.Sub(&bbn.corner.I, &i)
+	bbn.corner = BigComplex{cr, ci}
+}
+
 func (bbn *BigBaseNumerics) SubImage(rect image.Rectangle) {
-	min := bbn.PixelToPlane(rect.Min.X, rect.Min.Y)
-	max := bbn.PixelToPlane(rect.Max.X, rect.Max.Y)
+	topLeft := bbn.PixelToPlane(rect.Min.X, rect.Min.Y)
+	bottomRight := bbn.PixelToPlane(rect.Max.X, rect.Max.Y)
 
 	bbn.PictureSubImage(rect)
 
-	bbn.RealMin = min.R
-	bbn.ImagMin = min.I
-	bbn.RealMax = max.R
-	bbn.ImagMax = max.I
+	bbn.RealMin = topLeft.R
+	bbn.ImagMax = topLeft.I
+	bbn.RealMax = bottomRight.R
+	bbn.ImagMin = bottomRight.I
 }
 
 func (bbn *BigBaseNumerics) PixelToPlane(i, j int) BigComplex {
@@ -145,6 +210,24 @@ func (bbn *BigBaseNumerics) PixelToPlane(i, j int) BigComplex {
 	return BigComplex{re, extra}
 }
 
+// PixelPosition returns the point sampled by pixel (i, j).  Unlike PixelToPlane, it is measured
+// from the corner of the whole picture, so a pixel samples the same point however the picture
+// is divided by SubImage.
+func (bbn *BigBaseNumerics) PixelPosition(i, j int) BigComplex {
+	dx, dy := bbn.Jitter.At(i, j)
+	x := bbn.MakeBigFloat(float64(i) + dx)
+	y := bbn.MakeBigFloat(float64(j) + dy)
+	x.Mul(&x, &bbn.Runit)
+	y.Mul(&y, &bbn.Iunit)
+
+	re := bbn.MakeBigFloat(0.0)
+	re.Add(&bbn.corner.R, &x)
+	im := bbn.MakeBigFloat(0.0)
+	im.Sub(&bbn.corner.I, &y)
+
+	return BigComplex{re, im}
+}
+
 // Size on the plane of 1px
 func (bbn *BigBaseNumerics) PixelSize() (big.Float, big.Float) {
 	return bbn.Runit, bbn.Iunit
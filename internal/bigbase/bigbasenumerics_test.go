@@ -2,10 +2,11 @@ package bigbase
 
 import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
 	"testing"
 )
 
-// Three paths through Make
+// Make keeps the plane whatever the aspect ratio, which the configurator fixes beforehand
 // 1. Aspect ratio is okay
 // 2. Aspect ratio is too short
 // 3. Aspect ratio is too thin
@@ -35,7 +36,7 @@ func TestMake(t *testing.T) {
 		iMax: 0.5,
 
 		expectRMin: -0.5,
-		expectRMax: 1.5,
+		expectRMax: 0.5,
 		expectIMin: -0.5,
 		expectIMax: 0.5,
 	}
@@ -50,7 +51,7 @@ func TestMake(t *testing.T) {
 
 		expectRMin: -1.0,
 		expectRMax: 1.0,
-		expectIMin: -0.9,
+		expectIMin: -0.1,
 		expectIMax: 0.1,
 	}
 
@@ -68,9 +69,6 @@ func testMake(t *testing.T, helper aspectRatioFixHelper) {
 		MockRenderApplication: base.MockRenderApplication{
 			PictureWidth:  helper.pictureW,
 			PictureHeight: helper.pictureH,
-			Base: base.BaseConfig{
-				FixAspect: true,
-			},
 		},
 		MockBigCoordProvider: MockBigCoordProvider{
 			UserMin: userMin,
@@ -190,3 +188,31 @@ func TestPlaneToPixel(t *testing.T) {
 }
 
 const testPrec = uint(53)
+
+func TestSubImage(t *testing.T) {
+	numerics := BigBaseNumerics{
+		RealMin:   MakeBigFloat(-2.0, testPrec),
+		ImagMin:   MakeBigFloat(-1.0, testPrec),
+		RealMax:   MakeBigFloat(2.0, testPrec),
+		ImagMax:   MakeBigFloat(1.0, testPrec),
+		Precision: testPrec,
+	}
+	numerics.WholeWidth = 100
+	numerics.WholeHeight = 50
+	numerics.RestorePicBounds()
+	planeWidth := MakeBigFloat(4.0, testPrec)
+	planeHeight := MakeBigFloat(2.0, testPrec)
+	uq := UnitQuery{100, 50, &planeWidth, &planeHeight}
+	numerics.Runit, numerics.Iunit = uq.PixelUnits()
+
+	numerics.SubImage(image.Rect(25, 0, 50, 25))
+
+	expectMin := MakeBigComplex(-1.0, 0.0, testPrec)
+	expectMax := MakeBigComplex(0.0, 1.0, testPrec)
+	actualMin := BigComplex{numerics.RealMin, numerics.ImagMin}
+	actualMax := BigComplex{numerics.RealMax, numerics.ImagMax}
+	if !(BigComplexEq(&actualMin, &expectMin) && BigComplexEq(&actualMax, &expectMax)) {
+		t.Error("Expected ", DbgC(expectMin), DbgC(expectMax),
+			"but received", DbgC(actualMin), DbgC(actualMax))
+	}
+}
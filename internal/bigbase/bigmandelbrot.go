@@ -2,36 +2,225 @@ package bigbase
 
 import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math"
 	"math/big"
+	"math/cmplx"
 )
 
 type BigEscapeValue struct {
 	base.EscapeValue
+	// Point on the plane
 	C                *BigComplex
 	SqrtDivergeLimit *big.Float
 	Prec             uint
+	// Julia set constant, or nil for the Mandelbrot set
+	Seed *BigComplex
+	// Power of z.  Zero means 2.
+	Exponent uint
+	// Iteration formula, where nil means the Mandelbrot formula
+	Formula Formula
+	// Skip points inside the main cardioid and period-2 bulb.  Only valid for z^2 + c.
+	CardioidCheck bool
+	// Stop iterating when the orbit is found to cycle
+	PeriodicityCheck bool
+	// Track the derivative of the orbit to estimate distance from the set
+	TrackDerivative bool
+	// Size of a pixel on the plane, to scale the distance estimate.  This is a big float as
+	// deep zooms have pixels too small for a float64.
+	PixelSize *big.Float
+	// Find the period and interior distance of members
+	TrackInterior bool
+	// Closed when the render is cancelled, which stops iteration
+	Done <-chan struct{}
 }
 
-func (member *BigEscapeValue) Mandelbrot(iterateLimit uint8) {
+func (member *BigEscapeValue) Mandelbrot(iterateLimit uint32) {
+	if member.CardioidCheck && interior(member.C, member.Prec) {
+		member.InSet = true
+		member.InvDiv = iterateLimit
+		member.Smooth = float64(iterateLimit)
+		if member.TrackInterior {
+			c := nativec(*member.C)
+			z, period := base.BulbCycle(c)
+			member.InteriorEstimate(z, c, period, member.nativePixelSize())
+		}
+		return
+	}
+
 	z := MakeBigComplex(0.0, 0.0, member.Prec)
-	aa := MakeBigFloat(0.0, member.Prec)
-	bb := MakeBigFloat(0.0, member.Prec)
-	ab := MakeBigFloat(0.0, member.Prec)
-	i := uint8(0)
+	c := member.C
+	if member.Seed != nil {
+		z.R.Set(member.C.Real())
+		z.I.Set(member.C.Imag())
+		c = member.Seed
+	}
+	n := member.Exponent
+	formula := member.Formula
+	if formula == nil {
+		formula = MandelbrotFormula{}
+	}
+	work := MakeWorkspace(member.Prec)
+	// The derivative is taken by c for the Mandelbrot set, and by the initial z for Julia sets
+	derive := member.TrackDerivative
+	dz := scaledComplex{}
+	var dc complex128 = 1
+	if member.Seed != nil {
+		dz.m, dc = 1, 0
+	}
+	step := func() {
+		if derive {
+			dz.m = formula.Derive(nativec(z), dz.m, n) + dz.unscale(dc)
+			dz.normalize()
+		}
+		formula.Iterate(&z, c, n, &work)
+	}
+
+	// Brent's algorithm finds cycling orbits, which never escape
+	check := member.PeriodicityCheck
+	saved := MakeBigComplex(0.0, 0.0, member.Prec)
+	saved.R.Set(z.Real())
+	saved.I.Set(z.Imag())
+	period := uint32(1)
+	steps := uint32(0)
+
+	i := uint32(0)
 	for ; i < iterateLimit && withinMandLimit(&z, member.SqrtDivergeLimit); i++ {
-		aa.Mul(z.Real(), z.Real())
+		// The result is of no use once the render is cancelled
+		if base.Cancelled(member.Done) {
+			member.InSet = true
+			member.InvDiv = i
+			return
+		}
+
+		step()
 
-		bb.Mul(z.Imag(), z.Imag())
-		ab.Mul(z.Real(), z.Imag())
+		if !check {
+			continue
+		}
 
-		z.R.Copy(aa.Sub(&aa, &bb))
-		z.I.Copy(ab.Add(&ab, &ab))
+		if BigComplexEq(&z, &saved) {
+			i = iterateLimit
+			break
+		}
 
-		z.Add(&z, member.C)
+		steps++
+		if steps == period {
+			saved.R.Set(z.Real())
+			saved.I.Set(z.Imag())
+			period *= 2
+			steps = 0
+		}
 	}
 
 	member.InSet = i >= iterateLimit
 	member.InvDiv = i
+
+	if member.InSet {
+		member.Smooth = float64(iterateLimit)
+		if member.TrackInterior {
+			member.estimateInterior(&z, c, formula, iterateLimit, &work)
+		}
+		return
+	}
+
+	for k := uint32(0); k < base.SmoothIterations; k++ {
+		step()
+	}
+	member.Smooth = base.MultibrotSmoothEscape(i+base.SmoothIterations, cmplx.Abs(nativec(z)), n)
+
+	if derive && member.PixelSize != nil {
+		// The derivative and pixel size may each lie outside the range of a float64, though
+		// their product does not
+		mant := MakeBigFloat(0.0, member.PixelSize.Prec())
+		exp := member.PixelSize.MantExp(&mant)
+		pixelSize, _ := mant.Float64()
+		member.Estimate(nativec(z), dz.m, math.Ldexp(pixelSize, exp+dz.e))
+	}
+}
+
+// estimateInterior finds the period of the cycle that z has converged to, and for z^2 + c, the
+// distance to the boundary of its hyperbolic component.  Machine precision suffices for
+// colouring.
+func (member *BigEscapeValue) estimateInterior(z, c *BigComplex, formula Formula, iterateLimit uint32, work *Workspace) {
+	n := member.Exponent
+	w := MakeBigComplex(0.0, 0.0, member.Prec)
+	step := func(nw complex128) complex128 {
+		w.R.SetFloat64(real(nw))
+		w.I.SetFloat64(imag(nw))
+		formula.Iterate(&w, c, n, work)
+		return nativec(w)
+	}
+	period := base.Period(nativec(*z), step, iterateLimit)
+
+	if member.Seed != nil || n > 2 || !isMandelbrot(formula) {
+		member.Period = period
+		return
+	}
+	member.InteriorEstimate(nativec(*z), nativec(*c), period, member.nativePixelSize())
+}
+
+// nativePixelSize is the pixel size to machine precision, or zero if unknown.
+func (member *BigEscapeValue) nativePixelSize() float64 {
+	if member.PixelSize == nil {
+		return 0
+	}
+	size, _ := member.PixelSize.Float64()
+	return size
+}
+
+// scaledComplex is the complex number m * 2^e.  The exponent is kept apart so that derivatives
+// do not overflow at zooms too deep for a float64.
+type scaledComplex struct {
+	m complex128
+	e int
+}
+
+// unscale returns x / 2^e, to be added to the mantissa.
+func (s *scaledComplex) unscale(x complex128) complex128 {
+	return complex(math.Ldexp(real(x), -s.e), math.Ldexp(imag(x), -s.e))
+}
+
+// normalize moves the magnitude of the mantissa into the exponent.
+func (s *scaledComplex) normalize() {
+	_, exp := math.Frexp(math.Max(math.Abs(real(s.m)), math.Abs(imag(s.m))))
+	if exp == 0 {
+		return
+	}
+	s.m = complex(math.Ldexp(real(s.m), -exp), math.Ldexp(imag(s.m), -exp))
+	s.e += exp
+}
+
+// interior is true when c lies inside the main cardioid or the period-2 bulb of the
+// Mandelbrot set.
+func interior(c *BigComplex, prec uint) bool {
+	quarter := MakeBigFloat(0.25, prec)
+	one := MakeBigFloat(1.0, prec)
+	sixteenth := MakeBigFloat(0.0625, prec)
+
+	yy := MakeBigFloat(0.0, prec)
+	yy.Mul(c.Imag(), c.Imag())
+
+	// Main cardioid: q(q + x - 1/4) <= y^2 / 4, where q = (x - 1/4)^2 + y^2
+	xq := MakeBigFloat(0.0, prec)
+	xq.Sub(c.Real(), &quarter)
+	q := MakeBigFloat(0.0, prec)
+	q.Mul(&xq, &xq)
+	q.Add(&q, &yy)
+	lhs := MakeBigFloat(0.0, prec)
+	lhs.Add(&q, &xq)
+	lhs.Mul(&lhs, &q)
+	rhs := MakeBigFloat(0.0, prec)
+	rhs.Mul(&yy, &quarter)
+	if lhs.Cmp(&rhs) <= 0 {
+		return true
+	}
+
+	// Period-2 bulb: (x + 1)^2 + y^2 <= 1/16
+	xb := MakeBigFloat(0.0, prec)
+	xb.Add(c.Real(), &one)
+	xb.Mul(&xb, &xb)
+	xb.Add(&xb, &yy)
+	return xb.Cmp(&sixteenth) <= 0
 }
 
 func withinMandLimit(z *BigComplex, limit *big.Float) bool {
@@ -1,6 +1,7 @@
 package bigbase
 
 import (
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
 	"testing"
 )
 
@@ -8,7 +9,7 @@ func TestBigMandelbrotSanity(t *testing.T) {
 	origin := BigComplex{MakeBigFloat(0.0, testPrec), MakeBigFloat(0.0, testPrec)}
 	non := BigComplex{MakeBigFloat(2.0, testPrec), MakeBigFloat(4, testPrec)}
 	sqrtDL := MakeBigFloat(2.0, testPrec)
-	const iterateLimit uint8 = 255
+	const iterateLimit uint32 = 255
 
 	originMember := BigEscapeValue{
 		C:                &origin,
@@ -36,3 +37,239 @@ func TestBigMandelbrotSanity(t *testing.T) {
 		t.Error("Expected negativeMembership to have InvDivergence below IterateLimit")
 	}
 }
+
+func TestBigMandelbrotDeepIterations(t *testing.T) {
+	slow := BigComplex{MakeBigFloat(0.2501, testPrec), MakeBigFloat(0.0, testPrec)}
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	const iterateLimit uint32 = 1000
+
+	slowMember := BigEscapeValue{
+		C:                &slow,
+		SqrtDivergeLimit: &sqrtDL,
+		Prec:             testPrec,
+	}
+
+	slowMember.Mandelbrot(iterateLimit)
+
+	if slowMember.InSet {
+		t.Error("Expected ", slowMember, " to be outside Mandelbrot set")
+	}
+
+	if slowMember.InvDiv <= 255 {
+		t.Error("Expected slow escape beyond 255 iterations but was", slowMember.InvDiv)
+	}
+}
+
+func TestBigMandelbrotCancelled(t *testing.T) {
+	slow := BigComplex{MakeBigFloat(0.2501, testPrec), MakeBigFloat(0.0, testPrec)}
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	done := make(chan struct{})
+	close(done)
+
+	member := BigEscapeValue{
+		C:                &slow,
+		SqrtDivergeLimit: &sqrtDL,
+		Prec:             testPrec,
+		Done:             done,
+	}
+
+	member.Mandelbrot(1000)
+
+	if member.InvDiv != 0 {
+		t.Error("Expected no iterations after cancellation, but there were", member.InvDiv)
+	}
+}
+
+func TestBigJuliaMatchesNative(t *testing.T) {
+	const seed complex128 = -0.8 + 0.156i
+	const iterateLimit uint32 = 300
+	points := []complex128{0, 0.3 - 0.2i, -1.1 + 0.1i, 0.05 + 0.6i, 1.5}
+
+	bigSeed := MakeBigComplex(real(seed), imag(seed), testPrec)
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	for _, c := range points {
+		bigC := MakeBigComplex(real(c), imag(c), testPrec)
+		member := BigEscapeValue{
+			C:                &bigC,
+			SqrtDivergeLimit: &sqrtDL,
+			Prec:             testPrec,
+			Seed:             &bigSeed,
+		}
+		native := nativebase.NativeEscapeValue{
+			C:                c,
+			SqrtDivergeLimit: 2.0,
+			Julia:            true,
+			Seed:             seed,
+		}
+
+		member.Mandelbrot(iterateLimit)
+		native.Mandelbrot(iterateLimit)
+
+		if member.InSet != native.InSet || member.InvDiv != native.InvDiv {
+			t.Error("Big Julia escape", member.EscapeValue,
+				"differed from native escape", native.EscapeValue, "at", c)
+		}
+	}
+}
+
+func TestBigMultibrotMatchesNative(t *testing.T) {
+	const iterateLimit uint32 = 300
+	points := []complex128{0, 0.2, -0.5, 0.1 + 0.7i, -0.3 - 0.9i, 1.5}
+
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	for _, exponent := range []uint{3, 4} {
+		for _, c := range points {
+			bigC := MakeBigComplex(real(c), imag(c), testPrec)
+			member := BigEscapeValue{
+				C:                &bigC,
+				SqrtDivergeLimit: &sqrtDL,
+				Prec:             testPrec,
+				Exponent:         exponent,
+			}
+			native := nativebase.NativeEscapeValue{
+				C:                c,
+				SqrtDivergeLimit: 2.0,
+				Exponent:         exponent,
+			}
+
+			member.Mandelbrot(iterateLimit)
+			native.Mandelbrot(iterateLimit)
+
+			if member.InSet != native.InSet || member.InvDiv != native.InvDiv {
+				t.Error("Big Multibrot escape", member.EscapeValue,
+					"differed from native escape", native.EscapeValue,
+					"at", c, "with exponent", exponent)
+			}
+		}
+	}
+}
+
+func TestBigInteriorChecksMatch(t *testing.T) {
+	const iterateLimit uint32 = 300
+	points := []complex128{0, -0.5, 0.2 + 0.5i, -1.1, -0.75 + 0.1i, -0.1 + 0.9i, -1.76, 0.3}
+
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	for _, c := range points {
+		bigC := MakeBigComplex(real(c), imag(c), testPrec)
+		plain := BigEscapeValue{
+			C:                &bigC,
+			SqrtDivergeLimit: &sqrtDL,
+			Prec:             testPrec,
+		}
+		checked := plain
+		checked.CardioidCheck = true
+		checked.PeriodicityCheck = true
+
+		plain.Mandelbrot(iterateLimit)
+		checked.Mandelbrot(iterateLimit)
+
+		if plain.EscapeValue != checked.EscapeValue {
+			t.Error("Interior checks changed escape at", c, "from", plain.EscapeValue,
+				"to", checked.EscapeValue)
+		}
+	}
+}
+
+func TestBigDistanceMatchesNative(t *testing.T) {
+	const iterateLimit uint32 = 200
+	points := []complex128{0.3, -0.75 + 0.2i, -1.5 + 0.1i, 0.4 + 0.4i, -2.1}
+
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	pixelSize := MakeBigFloat(0.01, testPrec)
+	for _, c := range points {
+		bigC := MakeBigComplex(real(c), imag(c), testPrec)
+		member := BigEscapeValue{
+			C:                &bigC,
+			SqrtDivergeLimit: &sqrtDL,
+			Prec:             testPrec,
+			TrackDerivative:  true,
+			PixelSize:        &pixelSize,
+		}
+		member.Mandelbrot(iterateLimit)
+
+		native := nativebase.NativeEscapeValue{
+			C:                c,
+			SqrtDivergeLimit: 2.0,
+			TrackDerivative:  true,
+			PixelSize:        0.01,
+		}
+		native.Mandelbrot(iterateLimit)
+
+		diff := member.Distance - native.Distance
+		if diff > 0.000001 || diff < -0.000001 {
+			t.Error("Expected distance", native.Distance, "at", c, "but received", member.Distance)
+		}
+	}
+}
+
+// The Julia set of z^2 is the unit circle, so a point 1e-400 outside it is about one pixel of
+// size 1e-400 away, though its derivative is too large for a float64.
+func TestBigDistanceDeep(t *testing.T) {
+	const iterateLimit uint32 = 2000
+	const prec = 1500
+
+	epsilon := MakeBigFloat(0.0, prec)
+	epsilon.SetString("1e-400")
+	z := MakeBigComplex(1.0, 0.0, prec)
+	z.R.Add(z.Real(), &epsilon)
+	seed := MakeBigComplex(0.0, 0.0, prec)
+	sqrtDL := MakeBigFloat(2.0, prec)
+	member := BigEscapeValue{
+		C:                &z,
+		Seed:             &seed,
+		SqrtDivergeLimit: &sqrtDL,
+		Prec:             prec,
+		TrackDerivative:  true,
+		PixelSize:        &epsilon,
+	}
+	member.Mandelbrot(iterateLimit)
+
+	if member.InSet {
+		t.Fatal("Expected", z, "to escape")
+	}
+	if member.Distance < 0.5 || member.Distance > 2 {
+		t.Error("Expected distance of about one pixel but received", member.Distance)
+	}
+}
+
+func TestBigInteriorMatchesNative(t *testing.T) {
+	const iterateLimit uint32 = 300
+	points := []complex128{-0.1, -1.05, -1.7549, -0.1226 + 0.7449i, 0.4 + 0.4i}
+
+	sqrtDL := MakeBigFloat(2.0, testPrec)
+	pixelSize := MakeBigFloat(0.01, testPrec)
+	for _, c := range points {
+		for _, cardioid := range []bool{false, true} {
+			bigC := MakeBigComplex(real(c), imag(c), testPrec)
+			member := BigEscapeValue{
+				C:                &bigC,
+				SqrtDivergeLimit: &sqrtDL,
+				Prec:             testPrec,
+				CardioidCheck:    cardioid,
+				PeriodicityCheck: true,
+				TrackInterior:    true,
+				PixelSize:        &pixelSize,
+			}
+			member.Mandelbrot(iterateLimit)
+
+			native := nativebase.NativeEscapeValue{
+				C:                c,
+				SqrtDivergeLimit: 2.0,
+				CardioidCheck:    cardioid,
+				PeriodicityCheck: true,
+				TrackInterior:    true,
+				PixelSize:        0.01,
+			}
+			native.Mandelbrot(iterateLimit)
+
+			if member.Period != native.Period {
+				t.Error("Expected period", native.Period, "at", c, "but received", member.Period)
+			}
+
+			diff := member.Distance - native.Distance
+			if diff > 0.001 || diff < -0.001 {
+				t.Error("Expected distance", native.Distance, "at", c, "but received", member.Distance)
+			}
+		}
+	}
+}
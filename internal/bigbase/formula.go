@@ -0,0 +1,137 @@
+package bigbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+// Formula is an escape-time iteration, replacing z with the value that follows it in its orbit.
+// Exponent is the power z is raised to, where zero means 2.
+type Formula interface {
+	Iterate(z, c *BigComplex, exponent uint, work *Workspace)
+	// Derive returns the derivative of the iterated value, excluding c, given the derivative dz
+	// of z.  The derivative is only used for distance estimates, so machine precision suffices.
+	Derive(z, dz complex128, exponent uint) complex128
+}
+
+// Workspace holds temporary values, so formulae need not allocate on every iteration
+type Workspace struct {
+	aa big.Float
+	bb big.Float
+	ab big.Float
+	ba big.Float
+	zn BigComplex
+}
+
+func MakeWorkspace(prec uint) Workspace {
+	return Workspace{
+		aa: MakeBigFloat(0.0, prec),
+		bb: MakeBigFloat(0.0, prec),
+		ab: MakeBigFloat(0.0, prec),
+		ba: MakeBigFloat(0.0, prec),
+		zn: MakeBigComplex(0.0, 0.0, prec),
+	}
+}
+
+// Pow replaces z with z^n, where zero means 2
+func (work *Workspace) Pow(z *BigComplex, n uint) {
+	if n <= 2 {
+		work.aa.Mul(z.Real(), z.Real())
+		work.bb.Mul(z.Imag(), z.Imag())
+		work.ab.Mul(z.Real(), z.Imag())
+
+		z.R.Copy(work.aa.Sub(&work.aa, &work.bb))
+		z.I.Copy(work.ab.Add(&work.ab, &work.ab))
+		return
+	}
+
+	// Multibrot sets raise z to higher powers by repeated multiplication
+	zn := &work.zn
+	zn.R.Set(z.Real())
+	zn.I.Set(z.Imag())
+	for k := uint(1); k < n; k++ {
+		work.aa.Mul(zn.Real(), z.Real())
+		work.bb.Mul(zn.Imag(), z.Imag())
+		work.ab.Mul(zn.Real(), z.Imag())
+		work.ba.Mul(zn.Imag(), z.Real())
+
+		zn.R.Sub(&work.aa, &work.bb)
+		zn.I.Add(&work.ab, &work.ba)
+	}
+	z.R.Set(zn.Real())
+	z.I.Set(zn.Imag())
+}
+
+// MandelbrotFormula iterates z^n + c
+type MandelbrotFormula struct{}
+
+// isMandelbrot is true if formula is nil or the Mandelbrot formula
+func isMandelbrot(formula Formula) bool {
+	_, ok := formula.(MandelbrotFormula)
+	return formula == nil || ok
+}
+
+func (MandelbrotFormula) Iterate(z, c *BigComplex, exponent uint, work *Workspace) {
+	work.Pow(z, exponent)
+	z.Add(z, c)
+}
+
+func (MandelbrotFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	return base.PowerDerivative(z, dz, exponent)
+}
+
+// BurningShipFormula iterates (|Re z| + i|Im z|)^n + c
+type BurningShipFormula struct{}
+
+func (BurningShipFormula) Iterate(z, c *BigComplex, exponent uint, work *Workspace) {
+	z.R.Abs(z.Real())
+	z.I.Abs(z.Imag())
+	work.Pow(z, exponent)
+	z.Add(z, c)
+}
+
+func (BurningShipFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	folded := complex(math.Abs(real(z)), math.Abs(imag(z)))
+	dfolded := complex(sign(real(z))*real(dz), sign(imag(z))*imag(dz))
+	return base.PowerDerivative(folded, dfolded, exponent)
+}
+
+// TricornFormula iterates conj(z)^n + c.  It is also known as the Mandelbar set.
+type TricornFormula struct{}
+
+func (TricornFormula) Iterate(z, c *BigComplex, exponent uint, work *Workspace) {
+	z.I.Neg(z.Imag())
+	work.Pow(z, exponent)
+	z.Add(z, c)
+}
+
+func (TricornFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	return base.PowerDerivative(cmplx.Conj(z), cmplx.Conj(dz), exponent)
+}
+
+// CelticFormula iterates |Re z^n| + i Im z^n + c
+type CelticFormula struct{}
+
+func (CelticFormula) Iterate(z, c *BigComplex, exponent uint, work *Workspace) {
+	work.Pow(z, exponent)
+	z.R.Abs(z.Real())
+	z.Add(z, c)
+}
+
+func (CelticFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	var zn complex128 = 1
+	for k := uint(0); k < exponent || k < 2; k++ {
+		zn *= z
+	}
+	dzn := base.PowerDerivative(z, dz, exponent)
+	return complex(sign(real(zn))*real(dzn), imag(dzn))
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,42 @@
+package bigbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"testing"
+)
+
+func TestFormulaMatchesNative(t *testing.T) {
+	const z complex128 = -0.75 + 1.25i
+	const c complex128 = 0.5 - 0.25i
+
+	formulae := map[Formula]nativebase.Formula{
+		MandelbrotFormula{}:  nativebase.MandelbrotFormula{},
+		BurningShipFormula{}: nativebase.BurningShipFormula{},
+		TricornFormula{}:     nativebase.TricornFormula{},
+		CelticFormula{}:      nativebase.CelticFormula{},
+	}
+
+	work := MakeWorkspace(testPrec)
+	for formula, native := range formulae {
+		for _, exponent := range []uint{2, 3, 5} {
+			bigZ := MakeBigComplex(real(z), imag(z), testPrec)
+			bigC := MakeBigComplex(real(c), imag(c), testPrec)
+			formula.Iterate(&bigZ, &bigC, exponent, &work)
+
+			expect := native.Iterate(z, c, exponent)
+			actual := nativec(bigZ)
+			if actual != expect {
+				t.Error("Expected", formula, "with exponent", exponent,
+					"to give", expect, "but received", actual)
+			}
+
+			const dz complex128 = 0.25 + 2i
+			expectD := native.Derive(z, dz, exponent)
+			actualD := formula.Derive(z, dz, exponent)
+			if actualD != expectD {
+				t.Error("Expected", formula, "with exponent", exponent,
+					"to give derivative", expectD, "but received", actualD)
+			}
+		}
+	}
+}
@@ -13,11 +13,15 @@ var _ RenderApplication = (*MockRenderApplication)(nil)
 
 type MockBigCoordProvider struct {
 	TBigUserCoords bool
+	TBigJuliaSeed  bool
+	TBigFormula    bool
 	TPrecision     bool
 
-	UserMin BigComplex
-	UserMax BigComplex
-	Prec    uint
+	UserMin   BigComplex
+	UserMax   BigComplex
+	JuliaSeed BigComplex
+	Formula   Formula
+	Prec      uint
 }
 
 func (mbcp *MockBigCoordProvider) Precision() uint {
@@ -29,3 +33,13 @@ func (mbcp *MockBigCoordProvider) BigUserCoords() (*BigComplex, *BigComplex) {
 	mbcp.TBigUserCoords = true
 	return &mbcp.UserMin, &mbcp.UserMax
 }
+
+func (mbcp *MockBigCoordProvider) BigJuliaSeed() *BigComplex {
+	mbcp.TBigJuliaSeed = true
+	return &mbcp.JuliaSeed
+}
+
+func (mbcp *MockBigCoordProvider) BigFormula() Formula {
+	mbcp.TBigFormula = true
+	return mbcp.Formula
+}
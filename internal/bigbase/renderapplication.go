@@ -6,6 +6,8 @@ import (
 
 type BigCoordProvider interface {
 	BigUserCoords() (*BigComplex, *BigComplex)
+	BigJuliaSeed() *BigComplex
+	BigFormula() Formula
 	Precision() uint
 }
 
@@ -37,7 +37,7 @@ var _ sequence.SequenceNumerics = BigSequenceNumericsProxy{}
 func (proxy BigSequenceNumericsProxy) ClaimExtrinsics() {
 	base := proxy.BigSequenceNumerics.BigBaseNumerics
 	rectangle := proxy.LocalRegion.rect(&base)
-	proxy.BigSequenceNumerics.SubImage(rectangle)
+	proxy.BigSequenceNumerics.SubImage(rectangle.Intersect(proxy.TileRect()))
 }
 
 func (proxy BigSequenceNumericsProxy) Extrinsically(f func()) {
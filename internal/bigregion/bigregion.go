@@ -88,7 +88,6 @@ func (brn *BigRegionNumerics) Children() []region.RegionNumerics {
 	if brn.subregion.populated {
 		nextContexts := make([]region.RegionNumerics, 4)
 		for i, child := range brn.subregion.children {
-			// Use a proxy to avoid heap allocation
 			nextContexts[i] = brn.proxyNumerics(&child)
 		}
 		return nextContexts
@@ -99,8 +98,13 @@ func (brn *BigRegionNumerics) Children() []region.RegionNumerics {
 
 // RegionSequence returns ProxySequenceNumerics representing the same region on the plane.
 func (brn *BigRegionNumerics) RegionSequence() region.ProxySequence {
+	numerics := brn.SequenceNumerics
+	if brn.Concurrent {
+		copied := *numerics
+		numerics = &copied
+	}
 	return BigSequenceNumericsProxy{
-		BigSequenceNumerics: brn.SequenceNumerics,
+		BigSequenceNumerics: numerics,
 		LocalRegion:         brn.Region,
 	}
 }
@@ -203,8 +207,14 @@ func (brn *BigRegionNumerics) RegionMember() base.EscapeValue {
 }
 
 func (brn *BigRegionNumerics) proxyNumerics(region *bigRegion) region.RegionNumerics {
+	numerics := brn
+	if brn.Concurrent {
+		copied := *brn
+		copied.subregion = bigSubregion{}
+		numerics = &copied
+	}
 	return BigRegionNumericsProxy{
-		BigRegionNumerics: brn,
+		BigRegionNumerics: numerics,
 		LocalRegion:       *region,
 	}
 }
@@ -213,17 +223,17 @@ func (brn *BigRegionNumerics) Rect() image.Rectangle {
 	return brn.Region.rect(&brn.BigBaseNumerics)
 }
 
-func (brn *BigRegionNumerics) SampleDivs() (<-chan uint8, chan<- bool) {
+func (brn *BigRegionNumerics) SampleDivs() (<-chan uint32, chan<- bool) {
 	done := make(chan bool, 1)
-	idivch := make(chan uint8, 1)
+	idivch := make(chan uint32, 1)
 
 	go brn.sample(idivch, done)
 
 	return idivch, done
 }
 
-func (brn *BigRegionNumerics) sample(idivch chan<- uint8, done <-chan bool) {
-	complete := func(idiv uint8) bool {
+func (brn *BigRegionNumerics) sample(idivch chan<- uint32, done <-chan bool) {
+	complete := func(idiv uint32) bool {
 		select {
 		case <-done:
 			close(idivch)
@@ -234,7 +244,7 @@ func (brn *BigRegionNumerics) sample(idivch chan<- uint8, done <-chan bool) {
 		}
 	}
 
-	eval := func(r, i *big.Float) uint8 {
+	eval := func(r, i *big.Float) uint32 {
 		p := brn.Escape(&bigbase.BigComplex{*r, *i})
 		return p.InvDiv
 	}
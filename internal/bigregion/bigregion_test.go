@@ -282,8 +282,8 @@ func TestSampleDivs(t *testing.T) {
 	}
 }
 
-func slurp(idivch <-chan uint8) []uint8 {
-	out := []uint8{}
+func slurp(idivch <-chan uint32) []uint32 {
+	out := []uint32{}
 	for idiv := range idivch {
 		out = append(out, idiv)
 	}
@@ -30,28 +30,37 @@ func (bsn *BigSequenceNumerics) Sequence() []base.PixelMember {
 	area := (iright - ileft) * (ibott - itop)
 	out := make([]base.PixelMember, area)
 
-	pos := bigbase.BigComplex{
-		R: bsn.MakeBigFloat(0.0),
-		I: bsn.MakeBigFloat(0.0),
-	}
-	pos.R.Copy(&bsn.RealMin)
 	count := 0
 	member := bigbase.BigEscapeValue{
 		SqrtDivergeLimit: &bsn.SqrtDivergeLimit,
 		Prec:             bsn.Precision,
+		Seed:             bsn.JuliaSeed,
+		Exponent:         bsn.Exponent,
+		Formula:          bsn.Formula,
+		CardioidCheck:    bsn.CardioidCheck,
+		PeriodicityCheck: bsn.PeriodicityCheck,
+		TrackDerivative:  bsn.TrackDerivative,
+		PixelSize:        &bsn.Runit,
+		TrackInterior:    bsn.TrackInterior,
+		Done:             bsn.Done,
 	}
 	for i := ileft; i < iright; i++ {
-		pos.I.Copy(&bsn.ImagMax)
+		if bsn.Cancelled() {
+			break
+		}
+		column := count
 		for j := itop; j < ibott; j++ {
+			if !bsn.Lattice.Contains(i, j) {
+				continue
+			}
+			pos := bsn.PixelPosition(i, j)
 			member.C = &pos
 			member.Mandelbrot(iterlim)
 			out[count] = base.PixelMember{I: i, J: j, Member: member.EscapeValue}
-
-			pos.I.Sub(&pos.I, &bsn.Iunit)
 			count++
 		}
-		pos.R.Add(&pos.R, &bsn.Runit)
+		bsn.Tracker.AddPixels(count - column)
 	}
 
-	return out
+	return out[:count]
 }
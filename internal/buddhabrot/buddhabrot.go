@@ -0,0 +1,176 @@
+package buddhabrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"image"
+	"math/rand"
+	"sync"
+)
+
+// BuddhaRenderStrategy draws the density of escaping orbits, rather than colouring each
+// pixel by its escape time.  With a different iteration limit in each colour channel, this
+// is known as the Nebulabrot.
+type BuddhaRenderStrategy struct {
+	numerics nativebase.NativeBaseNumerics
+	context  draw.DrawingContext
+	config   BuddhaConfig
+}
+
+func Make(app RenderApplication) *BuddhaRenderStrategy {
+	return &BuddhaRenderStrategy{
+		numerics: nativebase.Make(app),
+		context:  app.DrawingContext(),
+		config:   app.BuddhaConfig(),
+	}
+}
+
+func (brs *BuddhaRenderStrategy) Render() (*image.NRGBA, error) {
+	hist := brs.Histogram()
+	pic := brs.context.Picture()
+	hist.Draw(pic)
+	return pic, nil
+}
+
+// ProgressivePasses is the number of passes over the samples in a progressive render
+const ProgressivePasses = 4
+
+// RenderProgressive samples the orbits in passes, calling progress with the picture of the
+// orbits so far after each pass but the last.  The finished picture is the same as Render's.
+func (brs *BuddhaRenderStrategy) RenderProgressive(progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	pic := brs.context.Picture()
+	hist := brs.histogram(ProgressivePasses, func(partial *Histogram) {
+		partial.Draw(pic)
+		progress(pic)
+	})
+	hist.Draw(pic)
+	return pic, nil
+}
+
+// Histogram samples the configured number of orbits, sharing the work between jobs
+func (brs *BuddhaRenderStrategy) Histogram() Histogram {
+	return brs.histogram(1, nil)
+}
+
+// histogram samples the orbits in passes, calling after with the histogram of the orbits so far
+// after each pass but the last.  Each job continues its own random sequence in every pass, so
+// the number of passes does not change the result.
+func (brs *BuddhaRenderStrategy) histogram(passes uint, after func(*Histogram)) Histogram {
+	jobs := uint(brs.config.Jobs)
+	if jobs == 0 {
+		jobs = 1
+	}
+	brs.numerics.Tracker.Begin(base.SamplePhase, int(brs.config.Samples), 0)
+
+	width, height := brs.numerics.PictureMax()
+	hists := make([]Histogram, jobs)
+	rngs := make([]*rand.Rand, jobs)
+	counts := make([]uint, jobs)
+	for j := uint(0); j < jobs; j++ {
+		counts[j] = brs.config.Samples / jobs
+		if j < brs.config.Samples%jobs {
+			counts[j]++
+		}
+		hists[j] = MakeHistogram(width, height)
+		// Seed each job separately, so renders are repeatable
+		rngs[j] = rand.New(rand.NewSource(int64(j)))
+	}
+
+	for p := uint(0); p < passes; p++ {
+		wg := sync.WaitGroup{}
+		for j := uint(0); j < jobs; j++ {
+			count := counts[j] / passes
+			if p < counts[j]%passes {
+				count++
+			}
+
+			wg.Add(1)
+			go func(j uint, count uint) {
+				defer wg.Done()
+				brs.sample(rngs[j], count, &hists[j])
+			}(j, count)
+		}
+		wg.Wait()
+
+		if p+1 < passes {
+			if brs.numerics.Cancelled() {
+				break
+			}
+			partial := MakeHistogram(width, height)
+			for j := range hists {
+				partial.Add(&hists[j])
+			}
+			after(&partial)
+		}
+	}
+
+	for j := uint(1); j < jobs; j++ {
+		hists[0].Add(&hists[j])
+	}
+	return hists[0]
+}
+
+// sample plots the orbits of count random points that escape
+func (brs *BuddhaRenderStrategy) sample(rng *rand.Rand, count uint, hist *Histogram) {
+	nbn := &brs.numerics
+	limits := brs.config.ChannelLimits
+	iterlim := uint32(0)
+	for _, l := range limits {
+		if l > iterlim {
+			iterlim = l
+		}
+	}
+
+	formula := nbn.Formula
+	if formula == nil {
+		formula = nativebase.MandelbrotFormula{}
+	}
+	n := nbn.Exponent
+
+	// Count progress in chunks, so that jobs do not contend for the tracker
+	const trackChunk = 1024
+	tracked := uint(0)
+	defer func() {
+		nbn.Tracker.AddPixels(int(tracked))
+	}()
+
+	// Points beyond the divergence limit escape at once, so need not be sampled
+	radius := nbn.SqrtDivergeLimit
+	for s := uint(0); s < count; s++ {
+		if nbn.Cancelled() {
+			return
+		}
+		if tracked == trackChunk {
+			nbn.Tracker.AddPixels(trackChunk)
+			tracked = 0
+		}
+		tracked++
+
+		r := ((2 * rng.Float64()) - 1) * radius
+		i := ((2 * rng.Float64()) - 1) * radius
+		c := complex(r, i)
+
+		member := nbn.CreateMandelbrot(c)
+		member.Mandelbrot(iterlim)
+		if member.InSet {
+			continue
+		}
+
+		escape := member.InvDiv
+		var z complex128 = 0
+		for k := uint32(0); k < escape; k++ {
+			z = formula.Iterate(z, c, n)
+			x, y := nbn.PlaneToPixel(z)
+			if x < 0 || y < 0 || x >= hist.Width || y >= hist.Height {
+				continue
+			}
+			px := (y * hist.Width) + x
+			for ch, l := range limits {
+				if escape <= l {
+					hist.Counts[ch][px]++
+				}
+			}
+		}
+	}
+}
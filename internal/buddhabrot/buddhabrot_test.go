@@ -0,0 +1,135 @@
+package buddhabrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestHistogramDraw(t *testing.T) {
+	hist := MakeHistogram(2, 1)
+	other := MakeHistogram(2, 1)
+	hist.Counts[Red][0] = 1
+	other.Counts[Red][0] = 3
+	other.Counts[Green][1] = 1
+	hist.Add(&other)
+
+	pic := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	hist.Draw(pic)
+
+	expect := []color.NRGBA{
+		{R: 255, G: 0, B: 0, A: 255},
+		{R: 0, G: 255, B: 0, A: 255},
+	}
+	for x, exp := range expect {
+		if actual := pic.NRGBAAt(x, 0); actual != exp {
+			t.Error("Expected", exp, "at", x, "but received", actual)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	const iterateLimit uint32 = 100
+	const size = 20
+
+	histogram := func(jobs uint16) Histogram {
+		app := &MockRenderApplication{}
+		app.PictureWidth = size
+		app.PictureHeight = size
+		app.Base = base.BaseConfig{DivergeLimit: 4.0, IterateLimit: iterateLimit}
+		app.PlaneMin = -2 - 2i
+		app.PlaneMax = 2 + 2i
+		app.Context = draw.NewMockDrawingContext(iterateLimit)
+		app.Config = BuddhaConfig{
+			Samples:       5000,
+			Jobs:          jobs,
+			ChannelLimits: [ChannelCount]uint32{iterateLimit, 20, 5},
+		}
+		return Make(app).Histogram()
+	}
+
+	hist := histogram(3)
+	var totals [ChannelCount]uint32
+	for ch, counts := range hist.Counts {
+		if len(counts) != size*size {
+			t.Fatal("Expected", size*size, "pixels but there were", len(counts))
+		}
+		for _, n := range counts {
+			totals[ch] += n
+		}
+	}
+
+	if totals[Red] == 0 {
+		t.Error("Expected orbits to be plotted")
+	}
+
+	// Channels with lower limits plot fewer orbits
+	if totals[Green] > totals[Red] || totals[Blue] > totals[Green] {
+		t.Error("Expected channel totals to fall with their limits, but were", totals)
+	}
+
+	again := histogram(3)
+	for ch := range hist.Counts {
+		for i := range hist.Counts[ch] {
+			if hist.Counts[ch][i] != again.Counts[ch][i] {
+				t.Fatal("Expected repeated render to give the same histogram")
+			}
+		}
+	}
+}
+
+func TestRenderProgressive(t *testing.T) {
+	const iterateLimit uint32 = 50
+	const samples = 3000
+
+	render := func(progressive bool) (*image.NRGBA, int, base.Progress) {
+		var last base.Progress
+		app := &MockRenderApplication{}
+		app.PictureWidth = 10
+		app.PictureHeight = 10
+		app.Base = base.BaseConfig{
+			DivergeLimit: 4.0,
+			IterateLimit: iterateLimit,
+			Tracker: base.NewTracker(func(p base.Progress) {
+				last = p
+			}),
+		}
+		app.PlaneMin = -2 - 2i
+		app.PlaneMax = 2 + 2i
+		context := draw.NewMockDrawingContext(iterateLimit)
+		context.Pic = image.NewNRGBA(image.Rect(0, 0, 10, 10))
+		app.Context = context
+		app.Config = BuddhaConfig{
+			Samples:       samples,
+			Jobs:          2,
+			ChannelLimits: [ChannelCount]uint32{iterateLimit, iterateLimit, iterateLimit},
+		}
+
+		calls := 0
+		var pic *image.NRGBA
+		if progressive {
+			pic, _ = Make(app).RenderProgressive(func(*image.NRGBA) {
+				calls++
+			})
+		} else {
+			pic, _ = Make(app).Render()
+		}
+		return pic, calls, last
+	}
+
+	whole, _, _ := render(false)
+	progressive, calls, last := render(true)
+	if calls != ProgressivePasses-1 {
+		t.Error("Expected", ProgressivePasses-1, "calls to progress but received", calls)
+	}
+	if last.Phase != base.SamplePhase || last.Pixels != samples || last.Total != samples {
+		t.Error("Expected every sample counted but received", last)
+	}
+	for i := range whole.Pix {
+		if whole.Pix[i] != progressive.Pix[i] {
+			t.Fatal("Expected progressive render to draw the same picture")
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package buddhabrot
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Histogram counts the number of times orbits pass through each pixel, for each colour channel
+type Histogram struct {
+	Width  int
+	Height int
+	Counts [ChannelCount][]uint32
+}
+
+func MakeHistogram(width, height int) Histogram {
+	hist := Histogram{Width: width, Height: height}
+	for ch := range hist.Counts {
+		hist.Counts[ch] = make([]uint32, width*height)
+	}
+	return hist
+}
+
+// Add the counts of another histogram of the same size
+func (hist *Histogram) Add(other *Histogram) {
+	for ch, counts := range other.Counts {
+		mine := hist.Counts[ch]
+		for i, n := range counts {
+			mine[i] += n
+		}
+	}
+}
+
+// Draw the histogram on to the picture.  Each channel is scaled by its greatest count, and
+// brightened by a square root so that faint orbits remain visible.
+func (hist *Histogram) Draw(picture *image.NRGBA) {
+	var max [ChannelCount]float64
+	for ch, counts := range hist.Counts {
+		for _, n := range counts {
+			if f := float64(n); f > max[ch] {
+				max[ch] = f
+			}
+		}
+	}
+
+	level := func(ch, i int) uint8 {
+		if max[ch] == 0 {
+			return 0
+		}
+		return uint8(255 * math.Sqrt(float64(hist.Counts[ch][i])/max[ch]))
+	}
+
+	for y := 0; y < hist.Height; y++ {
+		for x := 0; x < hist.Width; x++ {
+			i := (y * hist.Width) + x
+			picture.SetNRGBA(x, y, color.NRGBA{
+				R: level(Red, i),
+				G: level(Green, i),
+				B: level(Blue, i),
+				A: 255,
+			})
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package buddhabrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+)
+
+type MockRenderApplication struct {
+	nativebase.MockRenderApplication
+	draw.MockContextProvider
+	MockBuddhaProvider
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
+
+type MockBuddhaProvider struct {
+	TBuddhaConfig bool
+
+	Config BuddhaConfig
+}
+
+func (mock *MockBuddhaProvider) BuddhaConfig() BuddhaConfig {
+	mock.TBuddhaConfig = true
+	return mock.Config
+}
@@ -0,0 +1,33 @@
+package buddhabrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+)
+
+// Colour channels of the output image
+const (
+	Red = iota
+	Green
+	Blue
+	ChannelCount
+)
+
+type BuddhaConfig struct {
+	// Number of random points whose orbits are plotted
+	Samples uint
+	// Number of sampling threads
+	Jobs uint16
+	// Orbits are plotted in a channel only if they escape within its iteration limit
+	ChannelLimits [ChannelCount]uint32
+}
+
+type BuddhaProvider interface {
+	BuddhaConfig() BuddhaConfig
+}
+
+type RenderApplication interface {
+	nativebase.RenderApplication
+	draw.ContextProvider
+	BuddhaProvider
+}
@@ -0,0 +1,109 @@
+package ddbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"math"
+)
+
+// Basis for all double-double numerics
+type DDBaseNumerics struct {
+	base.BaseNumerics
+
+	RealMin DoubleDouble
+	RealMax DoubleDouble
+	ImagMin DoubleDouble
+	ImagMax DoubleDouble
+
+	Runit DoubleDouble
+	Iunit DoubleDouble
+
+	SqrtDivergeLimit float64
+	IterateLimit     uint32
+
+	Julia     bool
+	JuliaSeed DDComplex
+}
+
+func Make(app RenderApplication) DDBaseNumerics {
+	planeMin, planeMax := app.DDUserCoords()
+	planeWidth := planeMax.R.Sub(planeMin.R)
+	planeHeight := planeMax.I.Sub(planeMin.I)
+	pictureWidth, pictureHeight := app.PictureDimensions()
+	config := app.BaseConfig()
+
+	return DDBaseNumerics{
+		BaseNumerics: base.Make(app),
+		RealMin:      planeMin.R,
+		RealMax:      planeMax.R,
+		ImagMin:      planeMin.I,
+		ImagMax:      planeMax.I,
+
+		SqrtDivergeLimit: math.Sqrt(config.DivergeLimit),
+		IterateLimit:     config.IterateLimit,
+
+		Runit: planeWidth.QuoFloat(float64(pictureWidth)),
+		Iunit: planeHeight.QuoFloat(float64(pictureHeight)),
+
+		Julia:     config.Julia,
+		JuliaSeed: app.DDJuliaSeed(),
+	}
+}
+
+func (ddbn *DDBaseNumerics) CreateMandelbrot(c DDComplex) DDEscapeValue {
+	return DDEscapeValue{
+		C:                c,
+		SqrtDivergeLimit: ddbn.SqrtDivergeLimit,
+		Julia:            ddbn.Julia,
+		Seed:             ddbn.JuliaSeed,
+	}
+}
+
+// Size on the plane of 1px
+func (ddbn *DDBaseNumerics) PixelSize() (DoubleDouble, DoubleDouble) {
+	return ddbn.Runit, ddbn.Iunit
+}
+
+func (ddbn *DDBaseNumerics) PixelToPlane(i, j int) DDComplex {
+	return DDComplex{ddbn.Xtor(i), ddbn.Ytoi(j)}
+}
+
+func (ddbn *DDBaseNumerics) Xtor(i int) DoubleDouble {
+	sr := ddbn.Runit.MulFloat(float64(i))
+
+	return ddbn.RealMin.Add(sr)
+}
+
+func (ddbn *DDBaseNumerics) Ytoi(j int) DoubleDouble {
+	si := ddbn.Iunit.MulFloat(float64(j))
+
+	return ddbn.ImagMax.Sub(si)
+}
+
+func (ddbn *DDBaseNumerics) Escape(c DDComplex) DDEscapeValue {
+	point := ddbn.CreateMandelbrot(c)
+	point.Mandelbrot(ddbn.IterateLimit)
+	return point
+}
+
+func (ddbn *DDBaseNumerics) SubImage(rect image.Rectangle) {
+	topLeft := ddbn.PixelToPlane(rect.Min.X, rect.Min.Y)
+	bottomRight := ddbn.PixelToPlane(rect.Max.X, rect.Max.Y)
+
+	ddbn.PictureSubImage(rect)
+
+	ddbn.RealMin = topLeft.R
+	ddbn.ImagMax = topLeft.I
+	ddbn.RealMax = bottomRight.R
+	ddbn.ImagMin = bottomRight.I
+}
+
+// Offset moves the sample point of every pixel by a fraction of a pixel
+func (ddbn *DDBaseNumerics) Offset(dx, dy float64) {
+	r := ddbn.Runit.MulFloat(dx)
+	i := ddbn.Iunit.MulFloat(dy)
+	ddbn.RealMin = ddbn.RealMin.Add(r)
+	ddbn.RealMax = ddbn.RealMax.Add(r)
+	ddbn.ImagMin = ddbn.ImagMin.Sub(i)
+	ddbn.ImagMax = ddbn.ImagMax.Sub(i)
+}
@@ -0,0 +1,57 @@
+package ddbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"testing"
+)
+
+func makeMock() *MockRenderApplication {
+	mock := &MockRenderApplication{}
+	mock.Base = base.BaseConfig{DivergeLimit: 4.0, IterateLimit: 100}
+	mock.PictureWidth = 100
+	mock.PictureHeight = 50
+	mock.PlaneMin = MakeDDComplex(-2.0, -1.0)
+	mock.PlaneMax = MakeDDComplex(2.0, 1.0)
+	return mock
+}
+
+func TestMake(t *testing.T) {
+	mock := makeMock()
+	numerics := Make(mock)
+
+	if !(mock.TDDUserCoords && mock.TPictureDimensions && mock.TBaseConfig) {
+		t.Error("Expected methods not called on mock", mock)
+	}
+
+	rUnit, iUnit := numerics.PixelSize()
+	if rUnit.Float64() != 0.04 || iUnit.Float64() != 0.04 {
+		t.Error("Expected pixel size 0.04 but received", rUnit, iUnit)
+	}
+
+	if numerics.SqrtDivergeLimit != 2.0 {
+		t.Error("Expected SqrtDivergeLimit 2 but received", numerics.SqrtDivergeLimit)
+	}
+}
+
+func TestPixelToPlane(t *testing.T) {
+	numerics := Make(makeMock())
+
+	expect := complex(-1.0, 0.0)
+	actual := numerics.PixelToPlane(25, 25).Complex128()
+	if expect != actual {
+		t.Error("Expected", expect, "but received", actual)
+	}
+}
+
+func TestSubImage(t *testing.T) {
+	numerics := Make(makeMock())
+	numerics.SubImage(image.Rect(25, 0, 50, 25))
+
+	okay := numerics.RealMin.Float64() == -1.0 && numerics.RealMax.Float64() == 0.0
+	okay = okay && numerics.ImagMin.Float64() == 0.0 && numerics.ImagMax.Float64() == 1.0
+	if !okay {
+		t.Error("Unexpected plane after SubImage:", numerics.RealMin, numerics.RealMax,
+			numerics.ImagMin, numerics.ImagMax)
+	}
+}
@@ -0,0 +1,23 @@
+package ddbase
+
+import (
+	"math/big"
+)
+
+type DDComplex struct {
+	R DoubleDouble
+	I DoubleDouble
+}
+
+func MakeDDComplex(r, i float64) DDComplex {
+	return DDComplex{MakeDoubleDouble(r), MakeDoubleDouble(i)}
+}
+
+func FromBigComplex(r, i *big.Float) DDComplex {
+	return DDComplex{FromBig(r), FromBig(i)}
+}
+
+// Native approximation of the complex number
+func (c DDComplex) Complex128() complex128 {
+	return complex(c.R.Float64(), c.I.Float64())
+}
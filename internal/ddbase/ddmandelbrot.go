@@ -0,0 +1,62 @@
+package ddbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math/cmplx"
+)
+
+type DDEscapeValue struct {
+	base.EscapeValue
+	// Point on the plane
+	C                DDComplex
+	SqrtDivergeLimit float64
+	// Julia set constant, used when Julia is true
+	Julia bool
+	Seed  DDComplex
+}
+
+func (member *DDEscapeValue) Mandelbrot(iterateLimit uint32) {
+	var z DDComplex
+	sqrtDl := member.SqrtDivergeLimit
+	c := member.C
+	if member.Julia {
+		z = member.C
+		c = member.Seed
+	}
+	i := uint32(0)
+	for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
+		z = step(z, c)
+	}
+
+	member.InSet = i >= iterateLimit
+	member.InvDiv = i
+
+	if member.InSet {
+		member.Smooth = float64(iterateLimit)
+		return
+	}
+
+	// The point has escaped, so native precision is enough for the remaining iterations
+	nz := z.Complex128()
+	nc := c.Complex128()
+	for k := uint32(0); k < base.SmoothIterations; k++ {
+		nz = (nz * nz) + nc
+	}
+	member.Smooth = base.SmoothEscape(i+base.SmoothIterations, cmplx.Abs(nz))
+}
+
+// z^2 + c
+func step(z, c DDComplex) DDComplex {
+	sqr := z.R.Sqr().Sub(z.I.Sqr())
+	dbl := z.R.Mul(z.I)
+	dbl = DoubleDouble{2 * dbl.Hi, 2 * dbl.Lo}
+	return DDComplex{sqr.Add(c.R), dbl.Add(c.I)}
+}
+
+func withinMandLimit(z DDComplex, limit float64) bool {
+	// Approximate cmplx.Abs
+	negLimit := -limit
+	x := z.R.Hi
+	y := z.I.Hi
+	return x < limit && x > negLimit && y < limit && y > negLimit
+}
@@ -0,0 +1,76 @@
+package ddbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"math/big"
+	"testing"
+)
+
+func TestMandelbrotSanity(t *testing.T) {
+	const iterateLimit uint32 = 255
+	const sqrtDivergeLimit float64 = 2
+
+	originMember := DDEscapeValue{C: MakeDDComplex(0, 0), SqrtDivergeLimit: sqrtDivergeLimit}
+	nonMember := DDEscapeValue{C: MakeDDComplex(2, 4), SqrtDivergeLimit: sqrtDivergeLimit}
+
+	originMember.Mandelbrot(iterateLimit)
+	nonMember.Mandelbrot(iterateLimit)
+
+	if !originMember.InSet {
+		t.Error("Expected origin to be in Mandelbrot set")
+	}
+
+	if nonMember.InSet {
+		t.Error("Expected ", nonMember, " to be outside Mandelbrot set")
+	}
+}
+
+func TestMandelbrotMatchesNative(t *testing.T) {
+	const iterateLimit uint32 = 500
+	const sqrtDivergeLimit float64 = 2
+
+	points := []complex128{-0.75 + 0.1i, 0.3 + 0.5i, -1.5, 0.2501}
+
+	for _, c := range points {
+		dd := DDEscapeValue{C: MakeDDComplex(real(c), imag(c)), SqrtDivergeLimit: sqrtDivergeLimit}
+		native := nativebase.NativeEscapeValue{C: c, SqrtDivergeLimit: sqrtDivergeLimit}
+
+		dd.Mandelbrot(iterateLimit)
+		native.Mandelbrot(iterateLimit)
+
+		if dd.InSet != native.InSet || dd.InvDiv != native.InvDiv {
+			t.Error("At", c, "expected", native.EscapeValue, "but received", dd.EscapeValue)
+		}
+	}
+}
+
+func TestMandelbrotMatchesBig(t *testing.T) {
+	const prec uint = 106
+	const iterateLimit uint32 = 5000
+	const sqrtDivergeLimit float64 = 2
+
+	// Points near the boundary which escape after thousands of iterations
+	re := bigQuo(-7436438870371587, 1e16)
+	im := bigQuo(1318259042053119, 1e16)
+	nudge := big.NewFloat(1e-12)
+
+	for i := 1; i < 10; i++ {
+		r := new(big.Float).SetPrec(prec).Add(re, new(big.Float).Mul(nudge, big.NewFloat(float64(i))))
+		c := bigbase.BigComplex{R: *r, I: *new(big.Float).SetPrec(prec).Set(im)}
+
+		bigMember := bigbase.BigEscapeValue{
+			C:                &c,
+			Prec:             prec,
+			SqrtDivergeLimit: big.NewFloat(sqrtDivergeLimit).SetPrec(prec),
+		}
+		ddMember := DDEscapeValue{C: FromBigComplex(r, im), SqrtDivergeLimit: sqrtDivergeLimit}
+
+		bigMember.Mandelbrot(iterateLimit)
+		ddMember.Mandelbrot(iterateLimit)
+
+		if bigMember.InvDiv != ddMember.InvDiv {
+			t.Error("At", r, "expected", bigMember.InvDiv, "but received", ddMember.InvDiv)
+		}
+	}
+}
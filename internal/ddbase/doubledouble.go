@@ -0,0 +1,113 @@
+package ddbase
+
+import (
+	"math"
+	"math/big"
+)
+
+// DoubleDouble is an unevaluated sum of two float64s, giving roughly 106 bits of mantissa.
+// Hi holds the value rounded to float64 and Lo holds the rounding error.
+type DoubleDouble struct {
+	Hi float64
+	Lo float64
+}
+
+func MakeDoubleDouble(x float64) DoubleDouble {
+	return DoubleDouble{Hi: x}
+}
+
+// FromBig rounds a big.Float to the nearest DoubleDouble
+func FromBig(x *big.Float) DoubleDouble {
+	hi, _ := x.Float64()
+	prec := x.Prec()
+	if prec < 64 {
+		prec = 64
+	}
+	rem := big.NewFloat(0).SetPrec(prec)
+	rem.Sub(x, big.NewFloat(hi))
+	lo, _ := rem.Float64()
+	return renormalize(hi, lo)
+}
+
+// Big converts the DoubleDouble into a big.Float of the given precision
+func (a DoubleDouble) Big(prec uint) *big.Float {
+	x := big.NewFloat(a.Hi).SetPrec(prec)
+	return x.Add(x, big.NewFloat(a.Lo))
+}
+
+func (a DoubleDouble) Float64() float64 {
+	return a.Hi + a.Lo
+}
+
+func (a DoubleDouble) Neg() DoubleDouble {
+	return DoubleDouble{-a.Hi, -a.Lo}
+}
+
+func (a DoubleDouble) Add(b DoubleDouble) DoubleDouble {
+	s1, s2 := twoSum(a.Hi, b.Hi)
+	t1, t2 := twoSum(a.Lo, b.Lo)
+	s2 += t1
+	s1, s2 = quickTwoSum(s1, s2)
+	s2 += t2
+	return renormalize(s1, s2)
+}
+
+func (a DoubleDouble) Sub(b DoubleDouble) DoubleDouble {
+	return a.Add(b.Neg())
+}
+
+func (a DoubleDouble) Mul(b DoubleDouble) DoubleDouble {
+	p1, p2 := twoProd(a.Hi, b.Hi)
+	p2 += (a.Hi * b.Lo) + (a.Lo * b.Hi)
+	return renormalize(p1, p2)
+}
+
+func (a DoubleDouble) Sqr() DoubleDouble {
+	p1, p2 := twoProd(a.Hi, a.Hi)
+	p2 += 2 * a.Hi * a.Lo
+	p2 += a.Lo * a.Lo
+	return renormalize(p1, p2)
+}
+
+func (a DoubleDouble) MulFloat(b float64) DoubleDouble {
+	p1, p2 := twoProd(a.Hi, b)
+	p2 += a.Lo * b
+	return renormalize(p1, p2)
+}
+
+func (a DoubleDouble) QuoFloat(b float64) DoubleDouble {
+	q1 := a.Hi / b
+	p1, p2 := twoProd(q1, b)
+	s, e := twoSum(a.Hi, -p1)
+	e += a.Lo
+	e -= p2
+	q2 := (s + e) / b
+	return renormalize(q1, q2)
+}
+
+// Sum of two floats with the rounding error
+func twoSum(a, b float64) (float64, float64) {
+	s := a + b
+	bb := s - a
+	err := (a - (s - bb)) + (b - bb)
+	return s, err
+}
+
+// As twoSum, but requires |a| >= |b|
+func quickTwoSum(a, b float64) (float64, float64) {
+	s := a + b
+	err := b - (s - a)
+	return s, err
+}
+
+func renormalize(a, b float64) DoubleDouble {
+	s, err := quickTwoSum(a, b)
+	return DoubleDouble{s, err}
+}
+
+// Product of two floats with the rounding error
+func twoProd(a, b float64) (float64, float64) {
+	p := a * b
+	err := math.FMA(a, b, -p)
+	return p, err
+}
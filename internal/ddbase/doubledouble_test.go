@@ -0,0 +1,60 @@
+package ddbase
+
+import (
+	"math/big"
+	"testing"
+)
+
+const testPrec uint = 200
+
+func TestFromBig(t *testing.T) {
+	// One third cannot be represented in a single float64
+	third := big.NewFloat(1).SetPrec(testPrec)
+	third.Quo(third, big.NewFloat(3))
+
+	dd := FromBig(third)
+
+	if dd.Lo == 0 {
+		t.Error("Expected low part to hold rounding error but was", dd)
+	}
+
+	checkClose(t, third, dd)
+}
+
+func TestArithmetic(t *testing.T) {
+	a := FromBig(bigQuo(1, 3))
+	b := FromBig(bigQuo(-2, 7))
+
+	bigA := a.Big(testPrec)
+	bigB := b.Big(testPrec)
+
+	sum := new(big.Float).SetPrec(testPrec).Add(bigA, bigB)
+	diff := new(big.Float).SetPrec(testPrec).Sub(bigA, bigB)
+	prod := new(big.Float).SetPrec(testPrec).Mul(bigA, bigB)
+	sqr := new(big.Float).SetPrec(testPrec).Mul(bigA, bigA)
+	scaled := new(big.Float).SetPrec(testPrec).Mul(bigA, big.NewFloat(3.5))
+	quo := new(big.Float).SetPrec(testPrec).Quo(bigA, big.NewFloat(600))
+
+	checkClose(t, sum, a.Add(b))
+	checkClose(t, diff, a.Sub(b))
+	checkClose(t, prod, a.Mul(b))
+	checkClose(t, sqr, a.Sqr())
+	checkClose(t, scaled, a.MulFloat(3.5))
+	checkClose(t, quo, a.QuoFloat(600))
+}
+
+func bigQuo(x, y float64) *big.Float {
+	q := big.NewFloat(x).SetPrec(testPrec)
+	return q.Quo(q, big.NewFloat(y))
+}
+
+// Double-double arithmetic should be accurate to roughly 2^-104 relative error
+func checkClose(t *testing.T, expect *big.Float, actual DoubleDouble) {
+	diff := new(big.Float).SetPrec(testPrec).Sub(expect, actual.Big(testPrec))
+	diff.Abs(diff)
+	bound := new(big.Float).SetPrec(testPrec).Abs(expect)
+	bound.SetMantExp(bound, -100)
+	if diff.Cmp(bound) > 0 {
+		t.Error("Expected", expect, "but received", actual.Big(testPrec), "with error", diff)
+	}
+}
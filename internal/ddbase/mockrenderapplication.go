@@ -0,0 +1,31 @@
+package ddbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+)
+
+type MockRenderApplication struct {
+	base.MockRenderApplication
+	MockDDCoordProvider
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
+
+type MockDDCoordProvider struct {
+	TDDUserCoords bool
+	TDDJuliaSeed  bool
+
+	PlaneMin  DDComplex
+	PlaneMax  DDComplex
+	JuliaSeed DDComplex
+}
+
+func (mock *MockDDCoordProvider) DDUserCoords() (DDComplex, DDComplex) {
+	mock.TDDUserCoords = true
+	return mock.PlaneMin, mock.PlaneMax
+}
+
+func (mock *MockDDCoordProvider) DDJuliaSeed() DDComplex {
+	mock.TDDJuliaSeed = true
+	return mock.JuliaSeed
+}
@@ -0,0 +1,15 @@
+package ddbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+)
+
+type DDCoordProvider interface {
+	DDUserCoords() (DDComplex, DDComplex)
+	DDJuliaSeed() DDComplex
+}
+
+type RenderApplication interface {
+	base.RenderApplication
+	DDCoordProvider
+}
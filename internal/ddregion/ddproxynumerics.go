@@ -0,0 +1,49 @@
+package ddregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+	"github.com/johnny-morrice/godelbrot/internal/ddsequence"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type DDRegionProxy struct {
+	*DDRegionNumerics
+	LocalRegion ddRegion
+}
+
+// Check we implement the interface
+var _ region.RegionNumerics = DDRegionProxy{}
+
+func (proxy DDRegionProxy) ClaimExtrinsics() {
+	proxy.DDRegionNumerics.Region = proxy.LocalRegion
+}
+
+func (proxy DDRegionProxy) Extrinsically(f func()) {
+	old := proxy.DDRegionNumerics.Region
+	proxy.ClaimExtrinsics()
+	f()
+	proxy.DDRegionNumerics.Region = old
+}
+
+type DDSequenceProxy struct {
+	*ddsequence.DDSequenceNumerics
+	LocalRegion ddRegion
+}
+
+func (proxy DDSequenceProxy) ClaimExtrinsics() {
+	proxy.DDSequenceNumerics.SubImage(proxy.LocalRegion.rect().Intersect(proxy.TileRect()))
+}
+
+func (proxy DDSequenceProxy) Extrinsically(f func()) {
+	cmin := ddbase.DDComplex{R: proxy.RealMin, I: proxy.ImagMin}
+	cmax := ddbase.DDComplex{R: proxy.RealMax, I: proxy.ImagMax}
+
+	proxy.ClaimExtrinsics()
+	f()
+	proxy.RealMin = cmin.R
+	proxy.ImagMin = cmin.I
+	proxy.RealMax = cmax.R
+	proxy.ImagMax = cmax.I
+
+	proxy.RestorePicBounds()
+}
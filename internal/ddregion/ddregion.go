@@ -0,0 +1,234 @@
+package ddregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+	"github.com/johnny-morrice/godelbrot/internal/ddsequence"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+	"image"
+	"log"
+)
+
+type ddSubregion struct {
+	populated bool
+	children  []ddRegion
+}
+
+type ddRegion struct {
+	region.Region
+	topLeft     ddbase.DDEscapeValue
+	topRight    ddbase.DDEscapeValue
+	bottomLeft  ddbase.DDEscapeValue
+	bottomRight ddbase.DDEscapeValue
+	midPoint    ddbase.DDEscapeValue
+}
+
+func (dr *ddRegion) rect() image.Rectangle {
+	return image.Rect(dr.Xmin, dr.Ymin, dr.Xmax, dr.Ymax)
+}
+
+// Extend DDBaseNumerics and add support for regions
+type DDRegionNumerics struct {
+	region.RegionConfig
+	ddbase.DDBaseNumerics
+	Region           ddRegion
+	SequenceNumerics *ddsequence.DDSequenceNumerics
+	subregion        ddSubregion
+}
+
+// Check that we implement the interface
+var _ region.RegionNumerics = (*DDRegionNumerics)(nil)
+
+func Make(app RenderApplication) DDRegionNumerics {
+	sequence := ddsequence.Make(app)
+	parent := ddbase.Make(app)
+	reg := DDRegionNumerics{
+		DDBaseNumerics:   parent,
+		RegionConfig:     app.RegionConfig(),
+		SequenceNumerics: &sequence,
+	}
+	reg.initRegion()
+	return reg
+}
+
+func (dd *DDRegionNumerics) ClaimExtrinsics() {
+	// Region already present
+}
+
+func (dd *DDRegionNumerics) Extrinsically(f func()) {
+	f()
+}
+
+// Return the children of this region
+func (dd *DDRegionNumerics) Children() []region.RegionNumerics {
+	const childCount = 4
+	if dd.subregion.populated {
+		nextContexts := make([]region.RegionNumerics, childCount)
+		for i, child := range dd.subregion.children {
+			nextContexts[i] = dd.Proxy(child)
+		}
+		return nextContexts
+	}
+	log.Panic("Region asked to provide non-existent children")
+	return nil
+}
+
+func (dd *DDRegionNumerics) RegionSequence() region.ProxySequence {
+	return dd.DDSequence()
+}
+
+func (dd *DDRegionNumerics) DDSequence() DDSequenceProxy {
+	numerics := dd.SequenceNumerics
+	if dd.Concurrent {
+		copied := *numerics
+		numerics = &copied
+	}
+	return DDSequenceProxy{
+		LocalRegion:        dd.Region,
+		DDSequenceNumerics: numerics,
+	}
+}
+
+func (dd *DDRegionNumerics) Proxy(region ddRegion) DDRegionProxy {
+	numerics := dd
+	if dd.Concurrent {
+		copied := *dd
+		copied.subregion = ddSubregion{}
+		numerics = &copied
+	}
+	return DDRegionProxy{
+		LocalRegion:      region,
+		DDRegionNumerics: numerics,
+	}
+}
+
+func (dd *DDRegionNumerics) MandelbrotPoints() []base.EscapeValue {
+	ps := dd.Points()
+	base := make([]base.EscapeValue, len(ps))
+	for i, p := range ps {
+		base[i] = p.EscapeValue
+	}
+	return base
+}
+
+func (dd *DDRegionNumerics) Split() {
+	imgchlds := dd.Region.Split()
+
+	pchlds := make([]ddRegion, len(imgchlds))
+
+	for i, ic := range imgchlds {
+		pchlds[i] = dd.planeRegion(ic)
+	}
+
+	dd.subregion = ddSubregion{
+		populated: true,
+		children:  pchlds,
+	}
+}
+
+func (dd *DDRegionNumerics) planeRegion(r region.Region) ddRegion {
+	rmin := dd.Xtor(r.Xmin)
+	rmax := dd.Xtor(r.Xmax)
+	itop := dd.Ytoi(r.Ymin)
+	ibott := dd.Ytoi(r.Ymax)
+
+	rmid := rmin.Add(rmax).MulFloat(0.5)
+	imid := itop.Add(ibott).MulFloat(0.5)
+
+	dreg := ddRegion{}
+	dreg.topLeft = dd.Escape(ddbase.DDComplex{R: rmin, I: itop})
+	dreg.topRight = dd.Escape(ddbase.DDComplex{R: rmax, I: itop})
+	dreg.bottomLeft = dd.Escape(ddbase.DDComplex{R: rmin, I: ibott})
+	dreg.bottomRight = dd.Escape(ddbase.DDComplex{R: rmax, I: ibott})
+	dreg.midPoint = dd.Escape(ddbase.DDComplex{R: rmid, I: imid})
+	dreg.Region = r
+
+	return dreg
+}
+
+func (dd *DDRegionNumerics) Rect() image.Rectangle {
+	return dd.Region.rect()
+}
+
+// Return EscapeValue
+// Does not check if the region's Points have been evaluated
+func (dd *DDRegionNumerics) RegionMember() base.EscapeValue {
+	return dd.Region.topLeft.EscapeValue
+}
+
+func (dd *DDRegionNumerics) Points() []ddbase.DDEscapeValue {
+	region := dd.Region
+	return []ddbase.DDEscapeValue{
+		region.topLeft,
+		region.topRight,
+		region.bottomLeft,
+		region.bottomRight,
+		region.midPoint,
+	}
+}
+
+func (dd *DDRegionNumerics) SampleDivs() (<-chan uint32, chan<- bool) {
+	done := make(chan bool, 1)
+	idivch := make(chan uint32)
+
+	go dd.sample(idivch, done)
+
+	return idivch, done
+}
+
+func (dd *DDRegionNumerics) sample(idivch chan<- uint32, done <-chan bool) {
+	complete := func(idiv uint32) bool {
+		select {
+		case <-done:
+			close(idivch)
+			return true
+		default:
+			idivch <- idiv
+			return false
+		}
+	}
+
+	eval := func(r, i ddbase.DoubleDouble) uint32 {
+		p := dd.Escape(ddbase.DDComplex{R: r, I: i})
+		return p.InvDiv
+	}
+
+	// Provide the samples we already have
+	for _, p := range dd.Points() {
+		if complete(p.InvDiv) {
+			return
+		}
+	}
+
+	// Generate samples
+	tl := dd.Region.topLeft.C
+	br := dd.Region.bottomRight.C
+	count := dd.Samples
+	fCount := float64(count)
+	rmin := tl.R
+	rmax := br.R
+	imin := br.I
+	imax := tl.I
+	width := rmax.Sub(rmin)
+	height := imax.Sub(imin)
+	rUnit := width.QuoFloat(fCount)
+	iUnit := height.QuoFloat(fCount)
+	rdown := rmax
+	for i := uint(0); i < count; i++ {
+		rdown = rdown.Sub(rUnit)
+		idown := imax
+		for j := uint(0); j < count; j++ {
+			idown = idown.Sub(iUnit)
+			if complete(eval(rdown, idown)) {
+				return
+			}
+		}
+	}
+	close(idivch)
+}
+
+func (dd *DDRegionNumerics) initRegion() {
+	reg := region.InitRegion(&dd.BaseNumerics)
+
+	dd.Region = dd.planeRegion(reg)
+}
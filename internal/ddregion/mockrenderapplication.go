@@ -0,0 +1,15 @@
+package ddregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type MockRenderApplication struct {
+	ddbase.MockDDCoordProvider
+	region.MockRegionProvider
+	base.MockRenderApplication
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
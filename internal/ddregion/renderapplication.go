@@ -0,0 +1,13 @@
+package ddregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type RenderApplication interface {
+	ddbase.DDCoordProvider
+	region.RegionProvider
+	base.RenderApplication
+}
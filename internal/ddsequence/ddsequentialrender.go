@@ -0,0 +1,58 @@
+package ddsequence
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+	"github.com/johnny-morrice/godelbrot/internal/sequence"
+)
+
+type DDSequenceNumerics struct {
+	ddbase.DDBaseNumerics
+}
+
+// Check we implement interface
+var _ sequence.SequenceNumerics = (*DDSequenceNumerics)(nil)
+
+func Make(app ddbase.RenderApplication) DDSequenceNumerics {
+	return DDSequenceNumerics{
+		DDBaseNumerics: ddbase.Make(app),
+	}
+}
+
+func (ddsn *DDSequenceNumerics) Sequence() []base.PixelMember {
+	ileft, itop := ddsn.PictureMin()
+	iright, ibott := ddsn.PictureMax()
+	rUnit, iUnit := ddsn.PixelSize()
+	iterlim := ddsn.IterateLimit
+
+	area := (iright - ileft) * (ibott - itop)
+	out := make([]base.PixelMember, area)
+
+	count := 0
+	x := ddsn.RealMin
+	for i := ileft; i < iright; i++ {
+		if ddsn.Cancelled() {
+			break
+		}
+		column := count
+		y := ddsn.ImagMax
+		for j := itop; j < ibott; j++ {
+			if ddsn.Lattice.Contains(i, j) {
+				pos := ddbase.DDComplex{R: x, I: y}
+				if ddsn.Jitter.Cell != 0 {
+					dx, dy := ddsn.Jitter.At(i, j)
+					pos.R = pos.R.Add(rUnit.MulFloat(dx))
+					pos.I = pos.I.Sub(iUnit.MulFloat(dy))
+				}
+				member := ddsn.CreateMandelbrot(pos)
+				member.Mandelbrot(iterlim)
+				out[count] = base.PixelMember{I: i, J: j, Member: member.EscapeValue}
+				count++
+			}
+			y = y.Sub(iUnit)
+		}
+		ddsn.Tracker.AddPixels(count - column)
+		x = x.Add(rUnit)
+	}
+	return out[:count]
+}
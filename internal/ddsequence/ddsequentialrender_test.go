@@ -0,0 +1,53 @@
+package ddsequence
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/ddbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"github.com/johnny-morrice/godelbrot/internal/nativesequence"
+	"testing"
+)
+
+func TestDDMandelbrotSequence(t *testing.T) {
+	const iterLimit = 100
+	baseApp := base.MockRenderApplication{
+		Base:          base.BaseConfig{DivergeLimit: 4.0, IterateLimit: iterLimit},
+		PictureWidth:  10,
+		PictureHeight: 10,
+	}
+
+	app := &ddbase.MockRenderApplication{MockRenderApplication: baseApp}
+	app.PlaneMin = ddbase.MakeDDComplex(-2.0, -1.0)
+	app.PlaneMax = ddbase.MakeDDComplex(0.5, 1.0)
+	numerics := Make(app)
+	out := numerics.Sequence()
+
+	const expectedCount = 100
+	actualCount := len(out)
+
+	if expectedCount != actualCount {
+		t.Fatal("Expected", expectedCount, "members but there were", actualCount)
+	}
+
+	nativeApp := &nativebase.MockRenderApplication{MockRenderApplication: baseApp}
+	nativeApp.PlaneMin = complex(-2.0, -1.0)
+	nativeApp.PlaneMax = complex(0.5, 1.0)
+	native := nativesequence.Make(nativeApp)
+	expect := native.Sequence()
+
+	mismatch := 0
+	for i, px := range out {
+		ex := expect[i]
+		if px.I != ex.I || px.J != ex.J {
+			t.Fatal("Expected pixel", ex.I, ex.J, "but received", px.I, px.J)
+		}
+		if px.Member.InvDiv != ex.Member.InvDiv {
+			mismatch++
+		}
+	}
+
+	// Rounding differs between the two systems, so allow the odd boundary pixel to differ
+	if mismatch > 2 {
+		t.Error("Double-double differed from native on", mismatch, "pixels")
+	}
+}
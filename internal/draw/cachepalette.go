@@ -5,24 +5,34 @@ import (
 	"image/color"
 )
 
-type Cacher func(iterateLimit uint8, index uint8) color.NRGBA
+type Cacher func(iterateLimit uint32, index uint32) color.NRGBA
+
+// Most colours cached by a CachePalette.  Colours of deeper escapes are computed when needed,
+// so that huge iteration limits do not exhaust memory.
+const MaxCacheSize uint32 = 1 << 16
 
 type CachePalette struct {
 	memberColor color.NRGBA
 	scale       []color.NRGBA
-	limit       uint8
+	limit       uint32
+	cacher      Cacher
 }
 
-func NewCachePalette(iterateLimit uint8, member color.NRGBA, cacher Cacher) CachePalette {
-	colors := make([]color.NRGBA, iterateLimit, iterateLimit)
-	iLimit := int(iterateLimit)
-	for i := 0; i < iLimit; i++ {
-		colors[i] = cacher(iterateLimit, uint8(i))
+func NewCachePalette(iterateLimit uint32, member color.NRGBA, cacher Cacher) CachePalette {
+	size := iterateLimit
+	if size > MaxCacheSize {
+		size = MaxCacheSize
+	}
+	colors := make([]color.NRGBA, size, size)
+	iSize := int(size)
+	for i := 0; i < iSize; i++ {
+		colors[i] = cacher(iterateLimit, uint32(i))
 	}
 	return CachePalette{
 		memberColor: member,
 		scale:       colors,
 		limit:       iterateLimit,
+		cacher:      cacher,
 	}
 }
 
@@ -31,6 +41,51 @@ func (palette CachePalette) Color(member base.EscapeValue) color.NRGBA {
 	if member.InSet {
 		return palette.memberColor
 	} else {
-		return palette.scale[member.InvDiv]
+		return palette.color(member.InvDiv)
+	}
+}
+
+// color returns the colour at index of the scale
+func (palette CachePalette) color(index uint32) color.NRGBA {
+	if index < uint32(len(palette.scale)) {
+		return palette.scale[index]
+	}
+	return palette.cacher(palette.limit, index)
+}
+
+// CachePalette implements Interpolator by blending neighbouring colours in its scale
+func (palette CachePalette) Interpolate(member base.EscapeValue) color.NRGBA {
+	if member.InSet || palette.limit == 0 {
+		return palette.memberColor
+	}
+
+	last := palette.limit - 1
+	lower := member.Smooth
+	if lower > float64(last) {
+		lower = float64(last)
+	}
+	index := uint32(lower)
+	next := index
+	if next < last {
+		next++
+	}
+	frac := member.Smooth - float64(index)
+	if frac > 1 {
+		frac = 1
+	}
+
+	return blend(palette.color(index), palette.color(next), frac)
+}
+
+// Linear interpolation between two colours
+func blend(a, b color.NRGBA, frac float64) color.NRGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*frac + 0.5)
+	}
+	return color.NRGBA{
+		R: mix(a.R, b.R),
+		G: mix(a.G, b.G),
+		B: mix(a.B, b.B),
+		A: mix(a.A, b.A),
 	}
 }
@@ -7,9 +7,10 @@ import (
 )
 
 func TestColor(t *testing.T) {
-	const iterLimit uint8 = 10
-	cacher := func(iterLimit, index uint8) color.NRGBA {
-		return color.NRGBA{index, index, index, 255}
+	const iterLimit uint32 = 10
+	cacher := func(iterLimit, index uint32) color.NRGBA {
+		c := uint8(index)
+		return color.NRGBA{c, c, c, 255}
 	}
 	white := color.NRGBA{255, 255, 255, 255}
 	palette := NewCachePalette(iterLimit, white, cacher)
@@ -21,8 +22,9 @@ func TestColor(t *testing.T) {
 		t.Error("Expected white, but set member was assigned color:", actualInSet)
 	}
 
-	for i := uint8(0); i < iterLimit; i++ {
-		expect := color.NRGBA{i, i, i, 255}
+	for i := uint32(0); i < iterLimit; i++ {
+		c := uint8(i)
+		expect := color.NRGBA{c, c, c, 255}
 		member := base.EscapeValue{InvDiv: i}
 		actual := palette.Color(member)
 
@@ -31,3 +33,31 @@ func TestColor(t *testing.T) {
 		}
 	}
 }
+
+func TestColorBeyondCache(t *testing.T) {
+	const iterLimit uint32 = 1 << 31
+	cacher := func(iterLimit, index uint32) color.NRGBA {
+		c := uint8(index >> 16)
+		return color.NRGBA{c, c, c, 255}
+	}
+	white := color.NRGBA{255, 255, 255, 255}
+	palette := NewCachePalette(iterLimit, white, cacher)
+
+	if size := len(palette.scale); size != int(MaxCacheSize) {
+		t.Error("Expected cache of", MaxCacheSize, "colours but received", size)
+	}
+
+	for _, i := range []uint32{0, MaxCacheSize - 1, MaxCacheSize, 3 << 16, iterLimit - 1} {
+		expect := cacher(iterLimit, i)
+		member := base.EscapeValue{InvDiv: i}
+		if actual := palette.Color(member); expect != actual {
+			t.Error("Expected", expect, "at", i, "but received", actual)
+		}
+	}
+
+	deep := base.EscapeValue{Smooth: float64(3<<16) + 0.5}
+	expect := cacher(iterLimit, 3<<16)
+	if actual := palette.Interpolate(deep); expect != actual {
+		t.Error("Expected", expect, "but interpolated", actual)
+	}
+}
@@ -0,0 +1,209 @@
+package draw
+
+import (
+	"image/color"
+	"math"
+)
+
+// ColorSpace is a space in which colours are blended
+type ColorSpace uint8
+
+const (
+	RGBSpace = ColorSpace(iota)
+	HSVSpace
+	// CIE L*a*b*, in which equal distances look about equally different
+	LabSpace
+	// Polar form of L*a*b*, blending around the hue circle
+	LChSpace
+)
+
+// Blend mixes a fraction of colour b into colour a, blending in the colour space.  Alpha is
+// blended linearly.
+func (space ColorSpace) Blend(a, b color.NRGBA, frac float64) color.NRGBA {
+	if space == RGBSpace {
+		return blend(a, b, frac)
+	}
+
+	x := space.coords(a)
+	y := space.coords(b)
+	var mixed [3]float64
+	for i := range mixed {
+		mixed[i] = x[i] + ((y[i] - x[i]) * frac)
+	}
+
+	// Blend the shorter way around the hue circle.  Grays have no hue of their own.
+	if hue := space.hueIndex(); hue >= 0 {
+		hx, hy := x[hue], y[hue]
+		if x[1] < grayChroma {
+			hx = hy
+		} else if y[1] < grayChroma {
+			hy = hx
+		}
+		delta := hy - hx
+		delta -= math.Floor(delta + 0.5)
+		_, mixed[hue] = math.Modf(hx + (delta * frac) + 1)
+	}
+
+	col := space.color(mixed)
+	col.A = uint8(float64(a.A) + ((float64(b.A) - float64(a.A)) * frac) + 0.5)
+	return col
+}
+
+// Saturation or chroma below which a colour is considered gray
+const grayChroma = 1e-3
+
+// hueIndex returns the coordinate that is a hue, in turns, or -1 if there is none.  The
+// saturation or chroma is always the second coordinate.
+func (space ColorSpace) hueIndex() int {
+	switch space {
+	case HSVSpace:
+		return 0
+	case LChSpace:
+		return 2
+	default:
+		return -1
+	}
+}
+
+func (space ColorSpace) coords(col color.NRGBA) [3]float64 {
+	r, g, b := float64(col.R)/255, float64(col.G)/255, float64(col.B)/255
+	switch space {
+	case HSVSpace:
+		return rgbToHSV(r, g, b)
+	case LabSpace:
+		return rgbToLab(r, g, b)
+	case LChSpace:
+		lab := rgbToLab(r, g, b)
+		_, hue := math.Modf((math.Atan2(lab[2], lab[1]) / (2 * math.Pi)) + 1)
+		return [3]float64{lab[0], math.Hypot(lab[1], lab[2]), hue}
+	default:
+		return [3]float64{r, g, b}
+	}
+}
+
+func (space ColorSpace) color(coords [3]float64) color.NRGBA {
+	var r, g, b float64
+	switch space {
+	case HSVSpace:
+		r, g, b = hsvToRGB(coords[0], coords[1], coords[2])
+	case LabSpace:
+		r, g, b = labToRGB(coords)
+	case LChSpace:
+		angle := coords[2] * 2 * math.Pi
+		r, g, b = labToRGB([3]float64{coords[0], coords[1] * math.Cos(angle), coords[1] * math.Sin(angle)})
+	default:
+		r, g, b = coords[0], coords[1], coords[2]
+	}
+	return color.NRGBA{R: component(r), G: component(g), B: component(b), A: 255}
+}
+
+// component converts a colour component in [0, 1] to 8 bits
+func component(x float64) uint8 {
+	return uint8(math.Floor((math.Max(0, math.Min(1, x)) * 255.0) + 0.5))
+}
+
+// rgbToHSV converts a colour to hue, saturation and value, each in [0, 1]
+func rgbToHSV(r, g, b float64) [3]float64 {
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+
+	var h, s float64
+	if max > 0 {
+		s = delta / max
+	}
+	if delta > 0 {
+		switch max {
+		case r:
+			h = (g - b) / delta
+		case g:
+			h = ((b - r) / delta) + 2
+		default:
+			h = ((r - g) / delta) + 4
+		}
+		_, h = math.Modf((h / 6) + 1)
+	}
+	return [3]float64{h, s, max}
+}
+
+// hsvToRGB converts a colour from hue, saturation and value, each in [0, 1]
+func hsvToRGB(h, s, v float64) (float64, float64, float64) {
+	sector := h * 6.0
+	i := math.Floor(sector)
+	f := sector - i
+	p := v * (1.0 - s)
+	q := v * (1.0 - (s * f))
+	t := v * (1.0 - (s * (1.0 - f)))
+
+	switch int(i) % 6 {
+	case 0:
+		return v, t, p
+	case 1:
+		return q, v, p
+	case 2:
+		return p, v, t
+	case 3:
+		return p, q, v
+	case 4:
+		return t, p, v
+	default:
+		return v, p, q
+	}
+}
+
+// D65 white point
+const whiteX, whiteY, whiteZ = 0.95047, 1.0, 1.08883
+
+// rgbToLab converts an sRGB colour to CIE L*a*b*
+func rgbToLab(r, g, b float64) [3]float64 {
+	r, g, b = srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+	x := (0.4124564 * r) + (0.3575761 * g) + (0.1804375 * b)
+	y := (0.2126729 * r) + (0.7151522 * g) + (0.0721750 * b)
+	z := (0.0193339 * r) + (0.1191920 * g) + (0.9503041 * b)
+
+	fx, fy, fz := labF(x/whiteX), labF(y/whiteY), labF(z/whiteZ)
+	return [3]float64{(116 * fy) - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+// labToRGB converts a CIE L*a*b* colour to sRGB
+func labToRGB(lab [3]float64) (float64, float64, float64) {
+	fy := (lab[0] + 16) / 116
+	fx := fy + (lab[1] / 500)
+	fz := fy - (lab[2] / 200)
+	x, y, z := whiteX*labInvF(fx), whiteY*labInvF(fy), whiteZ*labInvF(fz)
+
+	r := (3.2404542 * x) - (1.5371385 * y) - (0.4985314 * z)
+	g := (-0.9692660 * x) + (1.8760108 * y) + (0.0415560 * z)
+	b := (0.0556434 * x) - (0.2040259 * y) + (1.0572252 * z)
+	return linearToSRGB(r), linearToSRGB(g), linearToSRGB(b)
+}
+
+const labDelta = 6.0 / 29.0
+
+func labF(t float64) float64 {
+	if t > labDelta*labDelta*labDelta {
+		return math.Cbrt(t)
+	}
+	return (t / (3 * labDelta * labDelta)) + (4.0 / 29.0)
+}
+
+func labInvF(t float64) float64 {
+	if t > labDelta {
+		return t * t * t
+	}
+	return 3 * labDelta * labDelta * (t - (4.0 / 29.0))
+}
+
+func srgbToLinear(x float64) float64 {
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(x float64) float64 {
+	if x <= 0.0031308 {
+		return x * 12.92
+	}
+	return (1.055 * math.Pow(x, 1.0/2.4)) - 0.055
+}
@@ -0,0 +1,83 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"math"
+)
+
+// CyclePalette repeats a palette every cycle of iterations, beginning part way through it, so
+// that its colours may be cycled for animation.
+type CyclePalette struct {
+	palette Palette
+	limit   float64
+	// Scale positions in the cycle to positions in the palette
+	scale float64
+	// Offset, in palette positions
+	shift float64
+}
+
+// NewCyclePalette creates a palette that repeats palette every cycle iterations, offset by a
+// fraction of the cycle.  A cycle of zero means the iteration limit.
+func NewCyclePalette(palette Palette, iterateLimit uint32, cycle uint32, offset float64) Palette {
+	if cycle == 0 {
+		cycle = iterateLimit
+	}
+	limit := float64(iterateLimit)
+	cp := CyclePalette{
+		palette: palette,
+		limit:   limit,
+		scale:   limit / float64(cycle),
+		shift:   offset * limit,
+	}
+	if interp, ok := palette.(Interpolator); ok {
+		return CycleInterpolator{CyclePalette: cp, interp: interp}
+	}
+	return cp
+}
+
+// CyclePalette implements Palette
+func (cp CyclePalette) Color(point base.EscapeValue) color.NRGBA {
+	if point.InSet || cp.limit == 0 {
+		return cp.palette.Color(point)
+	}
+	index := math.Floor(cp.position(float64(point.InvDiv)) + 0.5)
+	if index >= cp.limit {
+		index -= cp.limit
+	}
+	point.InvDiv = uint32(index)
+	return cp.palette.Color(point)
+}
+
+// position returns the position in the palette of an escape value
+func (cp CyclePalette) position(escape float64) float64 {
+	pos := math.Mod((escape*cp.scale)+cp.shift, cp.limit)
+	if pos < 0 {
+		pos += cp.limit
+	}
+	return pos
+}
+
+// CycleInterpolator is a CyclePalette of an Interpolator
+type CycleInterpolator struct {
+	CyclePalette
+	interp Interpolator
+}
+
+// CycleInterpolator implements Interpolator
+func (ci CycleInterpolator) Interpolate(point base.EscapeValue) color.NRGBA {
+	if point.InSet || ci.limit == 0 {
+		return ci.interp.Interpolate(point)
+	}
+	pos := ci.position(point.Smooth)
+	// Beyond the last colour, the cycle blends back into the first
+	if last := ci.limit - 1; pos > last {
+		point.Smooth = last
+		end := ci.interp.Interpolate(point)
+		point.Smooth = 0
+		start := ci.interp.Interpolate(point)
+		return blend(end, start, pos-last)
+	}
+	point.Smooth = pos
+	return ci.interp.Interpolate(point)
+}
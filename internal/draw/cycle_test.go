@@ -0,0 +1,83 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"testing"
+)
+
+func TestCyclePaletteOffset(t *testing.T) {
+	const limit = 100
+	gray := NewGrayscalePalette(limit)
+
+	palette := NewCyclePalette(gray, limit, 0, 0.5)
+
+	expect := map[uint32]uint32{
+		0:  50,
+		10: 60,
+		60: 10,
+	}
+	for invdiv, index := range expect {
+		actual := palette.Color(base.EscapeValue{InvDiv: invdiv})
+		if wanted := gray.Color(base.EscapeValue{InvDiv: index}); actual != wanted {
+			t.Error("Expected", invdiv, "to be coloured as", index, "but received", actual)
+		}
+	}
+
+	member := base.EscapeValue{InSet: true}
+	if palette.Color(member) != gray.Color(member) {
+		t.Error("Expected members of the set to keep their colour")
+	}
+
+	// A whole cycle of offset changes nothing
+	whole := NewCyclePalette(gray, limit, 0, 1)
+	for i := uint32(0); i < limit; i++ {
+		point := base.EscapeValue{InvDiv: i}
+		if whole.Color(point) != gray.Color(point) {
+			t.Error("Expected offset of one cycle to keep colour of", i)
+		}
+	}
+}
+
+func TestCyclePaletteCycle(t *testing.T) {
+	const limit = 100
+	gray := NewGrayscalePalette(limit).(Interpolator)
+
+	palette := NewCyclePalette(gray, limit, 25, 0)
+
+	for i := uint32(0); i < 25; i++ {
+		first := palette.Color(base.EscapeValue{InvDiv: i})
+		if wanted := gray.Color(base.EscapeValue{InvDiv: i * 4}); first != wanted {
+			t.Error("Expected", i, "to be coloured as", i*4, "but received", first)
+		}
+		again := palette.Color(base.EscapeValue{InvDiv: i + 25})
+		if again != first {
+			t.Error("Expected", i+25, "to repeat the colour of", i)
+		}
+	}
+
+	interp, ok := palette.(Interpolator)
+	if !ok {
+		t.Fatal("Expected cycled interpolator to interpolate")
+	}
+	actual := interp.Interpolate(base.EscapeValue{Smooth: 30.5})
+	if expect := gray.Interpolate(base.EscapeValue{Smooth: 22}); actual != expect {
+		t.Error("Expected", expect, "but received", actual)
+	}
+}
+
+func TestCyclePaletteWrap(t *testing.T) {
+	const limit = 100
+	gray := NewGrayscalePalette(limit).(Interpolator)
+
+	palette := NewCyclePalette(gray, limit, 0, 0).(Interpolator)
+
+	// Halfway between the last colour and the first
+	actual := palette.Interpolate(base.EscapeValue{Smooth: 99.5})
+	expect := blend(gray.Interpolate(base.EscapeValue{Smooth: 99}), gray.Interpolate(base.EscapeValue{}), 0.5)
+	if actual != expect {
+		t.Error("Expected", expect, "but received", actual)
+	}
+	if last := gray.Interpolate(base.EscapeValue{Smooth: 99}); actual == last {
+		t.Error("Expected the cycle to blend into its first colour")
+	}
+}
@@ -3,15 +3,26 @@ package draw
 import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
 	"image"
+	"image/draw"
 )
 
 type DrawingContext interface {
 	Picture() *image.NRGBA
 	Colors() Palette
+	// Escapes records the escape values drawn, or is nil if they are not recorded
+	Escapes() *EscapeMap
 }
 
 // DrawPoint draws a single point on to the image.
 func DrawPoint(context DrawingContext, pixel base.PixelMember) {
 	color := context.Colors().Color(pixel.Member)
 	context.Picture().Set(pixel.I, pixel.J, color)
+	Record(context, pixel)
+}
+
+// DrawBlock draws a rectangle in the colour of a single point.
+func DrawBlock(context DrawingContext, pixel base.PixelMember, block image.Rectangle) {
+	color := context.Colors().Color(pixel.Member)
+	draw.Draw(context.Picture(), block, image.NewUniform(color), image.ZP, draw.Src)
+	RecordRect(context, pixel.Member, block)
 }
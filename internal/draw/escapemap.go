@@ -0,0 +1,109 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+)
+
+// EscapeMap records the escape value of every sample of each pixel in a picture, so that the
+// picture can be coloured again without rendering it.
+type EscapeMap struct {
+	Rect image.Rectangle
+	// Samples of each pixel
+	Samples int
+	// Values of the samples, pixel by pixel in rows from the top left
+	Values []base.EscapeValue
+}
+
+// NewEscapeMap creates an escape map for the pixels in rect, each with the number of samples
+func NewEscapeMap(rect image.Rectangle, samples int) *EscapeMap {
+	if samples < 1 {
+		samples = 1
+	}
+	return &EscapeMap{
+		Rect:    rect,
+		Samples: samples,
+		Values:  make([]base.EscapeValue, rect.Dx()*rect.Dy()*samples),
+	}
+}
+
+// Set records the value of one sample of pixel (i, j)
+func (em *EscapeMap) Set(i, j, sample int, member base.EscapeValue) {
+	em.Values[em.index(i, j)+sample] = member
+}
+
+// SetPixel records the value of every sample of pixel (i, j)
+func (em *EscapeMap) SetPixel(i, j int, member base.EscapeValue) {
+	start := em.index(i, j)
+	for s := 0; s < em.Samples; s++ {
+		em.Values[start+s] = member
+	}
+}
+
+// At returns the value of one sample of pixel (i, j)
+func (em *EscapeMap) At(i, j, sample int) base.EscapeValue {
+	return em.Values[em.index(i, j)+sample]
+}
+
+// Draw colours the picture with the palette, averaging the samples of each pixel as
+// SampleBuffer does.
+func (em *EscapeMap) Draw(palette Palette, picture *image.NRGBA) {
+	for j := em.Rect.Min.Y; j < em.Rect.Max.Y; j++ {
+		if em.Samples == 1 {
+			for i := em.Rect.Min.X; i < em.Rect.Max.X; i++ {
+				picture.SetNRGBA(i, j, palette.Color(em.At(i, j, 0)))
+			}
+			continue
+		}
+
+		// Sample each row of pixels in the same order as SupersampleSequence
+		buf := SampleBuffer{}
+		row := make([]base.PixelMember, em.Rect.Dx())
+		for s := 0; s < em.Samples; s++ {
+			for x := range row {
+				i := em.Rect.Min.X + x
+				row[x] = base.PixelMember{I: i, J: j, Member: em.At(i, j, s)}
+			}
+			buf.Add(palette, row)
+		}
+		buf.Draw(picture)
+	}
+}
+
+// Record the value of every sample of a point drawn on to the context
+func Record(context DrawingContext, pixel base.PixelMember) {
+	if em := context.Escapes(); em != nil {
+		em.SetPixel(pixel.I, pixel.J, pixel.Member)
+	}
+}
+
+// RecordRect records the value of every sample of each pixel in a rectangle drawn on to the
+// context in the colour of a single point.
+func RecordRect(context DrawingContext, member base.EscapeValue, rect image.Rectangle) {
+	em := context.Escapes()
+	if em == nil {
+		return
+	}
+	for i := rect.Min.X; i < rect.Max.X; i++ {
+		for j := rect.Min.Y; j < rect.Max.Y; j++ {
+			em.SetPixel(i, j, member)
+		}
+	}
+}
+
+// RecordSample records one sample of each point in a supersampled picture
+func RecordSample(context DrawingContext, members []base.PixelMember, sample int) {
+	em := context.Escapes()
+	if em == nil {
+		return
+	}
+	for _, pix := range members {
+		em.Set(pix.I, pix.J, sample, pix.Member)
+	}
+}
+
+func (em *EscapeMap) index(i, j int) int {
+	x := i - em.Rect.Min.X
+	y := j - em.Rect.Min.Y
+	return ((y * em.Rect.Dx()) + x) * em.Samples
+}
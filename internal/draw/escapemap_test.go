@@ -0,0 +1,63 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"testing"
+)
+
+func TestEscapeMapSet(t *testing.T) {
+	em := NewEscapeMap(image.Rect(2, 3, 5, 7), 2)
+
+	if len(em.Values) != 3*4*2 {
+		t.Fatal("Expected 24 values but received", len(em.Values))
+	}
+
+	first := base.EscapeValue{InvDiv: 1}
+	second := base.EscapeValue{InvDiv: 2}
+	em.Set(4, 6, 1, second)
+	em.SetPixel(2, 3, first)
+
+	if em.At(4, 6, 1) != second || em.At(4, 6, 0) != (base.EscapeValue{}) {
+		t.Error("Expected only the second sample of (4, 6) to be set")
+	}
+
+	if em.At(2, 3, 0) != first || em.At(2, 3, 1) != first {
+		t.Error("Expected every sample of (2, 3) to be set")
+	}
+}
+
+func TestEscapeMapDraw(t *testing.T) {
+	rect := image.Rect(0, 0, 2, 1)
+	palette := NewRedscalePalette(10)
+	members := []base.PixelMember{
+		{I: 0, J: 0, Member: base.EscapeValue{InvDiv: 3}},
+		{I: 1, J: 0, Member: base.EscapeValue{InvDiv: 8}},
+	}
+	others := []base.PixelMember{
+		{I: 0, J: 0, Member: base.EscapeValue{InvDiv: 9}},
+		{I: 1, J: 0, Member: base.EscapeValue{InvDiv: 1}},
+	}
+	samples := [][]base.PixelMember{members, others}
+
+	em := NewEscapeMap(rect, len(samples))
+	mock := &MockDrawingContext{Pic: image.NewNRGBA(rect), Col: palette, Esc: em}
+	buf := SampleBuffer{}
+	for s, sample := range samples {
+		buf.Add(palette, sample)
+		RecordSample(mock, sample, s)
+	}
+	buf.Draw(mock.Pic)
+
+	if !mock.TEscapes {
+		t.Error("Expected method not called on mock drawing context:", mock)
+	}
+
+	recolored := image.NewNRGBA(rect)
+	em.Draw(palette, recolored)
+	for i := 0; i < 2; i++ {
+		if expect, actual := mock.Pic.NRGBAAt(i, 0), recolored.NRGBAAt(i, 0); expect != actual {
+			t.Error("Expected", expect, "at", i, "but received", actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// GradientMode decides the colour of positions beyond the end of a gradient
+type GradientMode uint8
+
+const (
+	// The colours at the ends continue
+	ClampMode = GradientMode(iota)
+	// The gradient begins again
+	RepeatMode
+	// The gradient runs backwards, then forwards again
+	MirrorMode
+)
+
+// GradientStop is a control point of a gradient
+type GradientStop struct {
+	// Position in [0, 1]
+	Position float64
+	Color    color.NRGBA
+}
+
+// Gradient blends colours between control points
+type Gradient struct {
+	// Control points in order of position.  Two stops at one position make a sharp edge.
+	Stops []GradientStop
+	Space ColorSpace
+	Mode  GradientMode
+	// Number of times the gradient spans the iteration range.  Zero means 1.
+	Repeats float64
+	// Colour of points in the set
+	InSet color.NRGBA
+}
+
+// At returns the colour at position t of the iteration range, which runs from 0 to 1
+func (g Gradient) At(t float64) color.NRGBA {
+	stops := g.Stops
+	if len(stops) == 0 {
+		return g.InSet
+	}
+
+	t = g.wrap(t)
+	if t <= stops[0].Position {
+		return stops[0].Color
+	}
+
+	// First stop after t
+	next := sort.Search(len(stops), func(i int) bool {
+		return stops[i].Position > t
+	})
+	if next == len(stops) {
+		return stops[next-1].Color
+	}
+
+	prev := stops[next-1]
+	frac := (t - prev.Position) / (stops[next].Position - prev.Position)
+	return g.Space.Blend(prev.Color, stops[next].Color, frac)
+}
+
+// wrap maps a position in the iteration range to a position in the gradient
+func (g Gradient) wrap(t float64) float64 {
+	repeats := g.Repeats
+	if repeats <= 0 {
+		repeats = 1
+	}
+	t *= repeats
+
+	switch g.Mode {
+	case RepeatMode:
+		return t - math.Floor(t)
+	case MirrorMode:
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+		return t
+	default:
+		return math.Max(0, math.Min(1, t))
+	}
+}
+
+// GradientPalette colours points by their position in a gradient
+type GradientPalette struct {
+	CachePalette
+	gradient Gradient
+}
+
+// NewGradientPalette creates a palette that spreads the gradient over the iteration range
+func NewGradientPalette(gradient Gradient, iterateLimit uint32) Palette {
+	cacher := func(limit uint32, index uint32) color.NRGBA {
+		return gradient.At(float64(index) / float64(limit))
+	}
+	return GradientPalette{
+		CachePalette: NewCachePalette(iterateLimit, gradient.InSet, cacher),
+		gradient:     gradient,
+	}
+}
+
+// GradientPalette implements Interpolator by blending in the colour space of the gradient
+func (gp GradientPalette) Interpolate(member base.EscapeValue) color.NRGBA {
+	if member.InSet || gp.limit == 0 {
+		return gp.gradient.InSet
+	}
+	return gp.gradient.At(member.Smooth / float64(gp.limit))
+}
@@ -0,0 +1,108 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"testing"
+)
+
+var black = color.NRGBA{0, 0, 0, 255}
+var white = color.NRGBA{255, 255, 255, 255}
+var red = color.NRGBA{255, 0, 0, 255}
+var blue = color.NRGBA{0, 0, 255, 255}
+
+func TestGradientAt(t *testing.T) {
+	grad := Gradient{
+		Stops: []GradientStop{
+			{Position: 0.25, Color: black},
+			{Position: 0.5, Color: white},
+			{Position: 0.5, Color: red},
+			{Position: 1, Color: blue},
+		},
+	}
+
+	expect := map[float64]color.NRGBA{
+		0:     black,
+		0.25:  black,
+		0.375: {128, 128, 128, 255},
+		0.5:   red,
+		0.75:  {128, 0, 128, 255},
+		1.5:   blue,
+	}
+	for pos, col := range expect {
+		if actual := grad.At(pos); actual != col {
+			t.Error("Expected", col, "at", pos, "but received", actual)
+		}
+	}
+}
+
+func TestGradientModes(t *testing.T) {
+	stops := []GradientStop{{Position: 0, Color: black}, {Position: 1, Color: white}}
+	gray := color.NRGBA{128, 128, 128, 255}
+
+	// Twice over the iteration range, positions 0, 0.5 and 0.75 lie at 0, 1 and 1.5
+	positions := []float64{0, 0.5, 0.75}
+	expect := map[GradientMode][]color.NRGBA{
+		ClampMode:  {black, white, white},
+		RepeatMode: {black, black, gray},
+		MirrorMode: {black, white, gray},
+	}
+	for mode, cols := range expect {
+		grad := Gradient{Stops: stops, Mode: mode, Repeats: 2}
+		for i, col := range cols {
+			pos := positions[i]
+			if actual := grad.At(pos); actual != col {
+				t.Error("Mode", mode, "expected", col, "at", pos, "but received", actual)
+			}
+		}
+	}
+}
+
+func TestColorSpaceBlend(t *testing.T) {
+	for _, space := range []ColorSpace{RGBSpace, HSVSpace, LabSpace, LChSpace} {
+		for _, col := range []color.NRGBA{black, white, red, {12, 200, 99, 255}} {
+			if actual := space.Blend(col, col, 0.5); actual != col {
+				t.Error("Space", space, "expected", col, "to survive conversion, but received", actual)
+			}
+		}
+	}
+
+	// Red and blue are closer through magenta than through green
+	if mid := HSVSpace.Blend(red, blue, 0.5); mid != (color.NRGBA{255, 0, 255, 255}) {
+		t.Error("Expected HSV blend through magenta but received", mid)
+	}
+
+	// Grays take the hue of the other colour
+	if mid := HSVSpace.Blend(white, red, 0.5); mid != (color.NRGBA{255, 128, 128, 255}) {
+		t.Error("Expected HSV blend from white to keep red hue but received", mid)
+	}
+
+	// Alpha is blended linearly
+	clear := color.NRGBA{255, 0, 0, 0}
+	if mid := LabSpace.Blend(clear, red, 0.5); mid.A != 128 {
+		t.Error("Expected half transparency but received", mid)
+	}
+}
+
+func TestGradientPalette(t *testing.T) {
+	inset := color.NRGBA{1, 2, 3, 0}
+	grad := Gradient{
+		Stops: []GradientStop{{Position: 0, Color: black}, {Position: 1, Color: white}},
+		InSet: inset,
+	}
+	palette := NewGradientPalette(grad, 10).(GradientPalette)
+
+	if actual := palette.Color(base.EscapeValue{InvDiv: 5}); actual != grad.At(0.5) {
+		t.Error("Expected colour halfway along gradient but received", actual)
+	}
+
+	if actual := palette.Interpolate(base.EscapeValue{InvDiv: 2, Smooth: 2.5}); actual != grad.At(0.25) {
+		t.Error("Expected colour a quarter along gradient but received", actual)
+	}
+
+	for _, member := range []base.EscapeValue{{InSet: true}} {
+		if palette.Color(member) != inset || palette.Interpolate(member) != inset {
+			t.Error("Expected in set colour")
+		}
+	}
+}
@@ -8,7 +8,7 @@ type GrayscalePalette struct {
 	CachePalette
 }
 
-func NewGrayscalePalette(iterateLimit uint8) Palette {
+func NewGrayscalePalette(iterateLimit uint32) Palette {
 	white := color.NRGBA{
 		R: 255, G: 255, B: 255, A: 255,
 	}
@@ -18,7 +18,7 @@ func NewGrayscalePalette(iterateLimit uint8) Palette {
 }
 
 // Cache redscale colour values
-func grayCache(limit uint8, index uint8) color.NRGBA {
+func grayCache(limit uint32, index uint32) color.NRGBA {
 	calibIndex := float64(index)
 	interval := 255.0 / float64(limit)
 	gray := uint8(calibIndex * interval)
@@ -0,0 +1,86 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"sort"
+)
+
+// HistogramPalette colours points by the fraction of the render that escaped before them, so
+// that colours are spread evenly over the picture even when most points escape at similar
+// iterations.
+type HistogramPalette struct {
+	palette Interpolator
+	smooth  bool
+	// Highest index into the palette
+	scale float64
+	// Iterations of the escaping points, in order.  These are kept rather than a bin for each
+	// iteration, as the iterate limit may be far larger than the render.
+	escapes []uint32
+}
+
+// NewHistogramPalette creates a palette that colours by the histogram of values, using the
+// scale of palette.  If smooth is true, colours are interpolated by the continuous escape
+// value.
+func NewHistogramPalette(palette Interpolator, iterateLimit uint32, values []base.EscapeValue, smooth bool) Palette {
+	escapes := []uint32{}
+	for _, ev := range values {
+		if ev.InSet || ev.InvDiv >= iterateLimit {
+			continue
+		}
+		escapes = append(escapes, ev.InvDiv)
+	}
+	sort.Slice(escapes, func(i, j int) bool {
+		return escapes[i] < escapes[j]
+	})
+
+	return HistogramPalette{
+		palette: palette,
+		smooth:  smooth,
+		scale:   float64(iterateLimit) - 1,
+		escapes: escapes,
+	}
+}
+
+// HistogramPalette implements Palette
+func (hp HistogramPalette) Color(point base.EscapeValue) color.NRGBA {
+	if point.InSet || hp.scale < 0 {
+		return hp.palette.Color(point)
+	}
+
+	if hp.smooth {
+		point.Smooth = hp.fraction(point.Smooth) * hp.scale
+		return hp.palette.Interpolate(point)
+	}
+
+	escaped := hp.cumulative(uint64(point.InvDiv) + 1)
+	point.InvDiv = uint32((escaped * hp.scale) + 0.5)
+	return hp.palette.Color(point)
+}
+
+// cumulative is the fraction of escaping points that escaped in fewer than i iterations
+func (hp HistogramPalette) cumulative(i uint64) float64 {
+	if len(hp.escapes) == 0 {
+		return 0
+	}
+	count := sort.Search(len(hp.escapes), func(j int) bool {
+		return uint64(hp.escapes[j]) >= i
+	})
+	return float64(count) / float64(len(hp.escapes))
+}
+
+// fraction interpolates the cumulative histogram at a continuous escape value
+func (hp HistogramPalette) fraction(smooth float64) float64 {
+	if smooth <= 0 {
+		return hp.cumulative(0)
+	}
+	if smooth > hp.scale {
+		return hp.cumulative(uint64(hp.scale) + 1)
+	}
+
+	index := uint64(smooth)
+	frac := smooth - float64(index)
+	lower := hp.cumulative(index)
+	upper := hp.cumulative(index + 1)
+	return lower + ((upper - lower) * frac)
+}
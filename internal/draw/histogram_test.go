@@ -0,0 +1,74 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math"
+	"testing"
+)
+
+func TestHistogramPalette(t *testing.T) {
+	const limit = 100
+	gray := NewGrayscalePalette(limit).(Interpolator)
+
+	// Most points escape after 10 iterations, crowding the linear scale
+	values := []base.EscapeValue{{InSet: true}}
+	for i := 0; i < 3; i++ {
+		values = append(values, base.EscapeValue{InvDiv: 10})
+	}
+	values = append(values, base.EscapeValue{InvDiv: 11})
+
+	palette := NewHistogramPalette(gray, limit, values, false)
+
+	expect := map[uint32]uint32{
+		10: 74,
+		11: 99,
+		5:  0,
+	}
+	for invdiv, index := range expect {
+		actual := palette.Color(base.EscapeValue{InvDiv: invdiv})
+		if wanted := gray.Color(base.EscapeValue{InvDiv: index}); actual != wanted {
+			t.Error("Expected", invdiv, "to be coloured as", index, "but received", actual)
+		}
+	}
+
+	member := base.EscapeValue{InSet: true}
+	if palette.Color(member) != gray.Color(member) {
+		t.Error("Expected members of the set to keep their colour")
+	}
+}
+
+func TestHistogramPaletteSmooth(t *testing.T) {
+	const limit = 100
+	gray := NewGrayscalePalette(limit).(Interpolator)
+	values := []base.EscapeValue{{InvDiv: 10}, {InvDiv: 20}}
+
+	palette := NewHistogramPalette(gray, limit, values, true)
+
+	// Halfway through the bin of 10 iterations is a quarter of the way through the render
+	actual := palette.Color(base.EscapeValue{InvDiv: 10, Smooth: 10.5})
+	expect := gray.Interpolate(base.EscapeValue{Smooth: 0.25 * 99})
+	if actual != expect {
+		t.Error("Expected", expect, "but received", actual)
+	}
+}
+
+// TestHistogramPaletteHugeLimit checks that the histogram is sized by the values rather than the
+// iterate limit
+func TestHistogramPaletteHugeLimit(t *testing.T) {
+	const limit = math.MaxUint32
+	gray := NewGrayscalePalette(limit).(Interpolator)
+	values := []base.EscapeValue{{InvDiv: 3}, {InvDiv: 7}, {InSet: true}}
+
+	palette := NewHistogramPalette(gray, limit, values, false)
+
+	actual := palette.Color(base.EscapeValue{InvDiv: 3})
+	if expect := gray.Color(base.EscapeValue{InvDiv: limit / 2}); actual != expect {
+		t.Error("Expected", expect, "but received", actual)
+	}
+
+	actual = palette.Color(base.EscapeValue{InvDiv: 1000})
+	expect := gray.Color(base.EscapeValue{InvDiv: limit - 1})
+	if actual != expect {
+		t.Error("Expected", expect, "but received", actual)
+	}
+}
@@ -0,0 +1,67 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"math"
+)
+
+// PeriodPalette colours members of the set by the period of their attracting cycle, showing
+// the hyperbolic components.  Members of unknown period keep the colour of the wrapped palette.
+type PeriodPalette struct {
+	Palette
+}
+
+func NewPeriodPalette(palette Palette) Palette {
+	return PeriodPalette{palette}
+}
+
+// PeriodPalette implements Palette
+func (pp PeriodPalette) Color(point base.EscapeValue) color.NRGBA {
+	if !point.InSet || point.Period == 0 {
+		return pp.Palette.Color(point)
+	}
+	return periodColor(point.Period)
+}
+
+// InteriorDistancePalette darkens members of the set close to the boundary of their hyperbolic
+// component.
+type InteriorDistancePalette struct {
+	Palette
+	// Distance in pixels over which members brighten
+	Falloff float64
+}
+
+func NewInteriorDistancePalette(palette Palette) Palette {
+	return InteriorDistancePalette{Palette: palette, Falloff: 8.0}
+}
+
+// InteriorDistancePalette implements Palette
+func (idp InteriorDistancePalette) Color(point base.EscapeValue) color.NRGBA {
+	col := idp.Palette.Color(point)
+	if !point.InSet || point.Distance == 0 {
+		return col
+	}
+
+	t := 1.0 - math.Exp(-point.Distance/idp.Falloff)
+	return shade(col, t)
+}
+
+// periodColor spreads periods around the colour wheel by the golden ratio, so that nearby
+// periods have distinct hues.
+func periodColor(period uint32) color.NRGBA {
+	const golden = 0.618033988749895
+	_, hue := math.Modf(float64(period) * golden)
+	return hsv(hue, 0.6, 0.95)
+}
+
+// hsv converts a colour from hue, saturation and value, each in [0, 1]
+func hsv(h, s, v float64) color.NRGBA {
+	r, g, b := hsvToRGB(h, s, v)
+	return color.NRGBA{
+		R: uint8(r * 255),
+		G: uint8(g * 255),
+		B: uint8(b * 255),
+		A: 255,
+	}
+}
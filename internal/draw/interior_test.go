@@ -0,0 +1,44 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"testing"
+)
+
+func TestPeriodPalette(t *testing.T) {
+	black := color.NRGBA{0, 0, 0, 255}
+	palette := NewPeriodPalette(NewRedscalePalette(10))
+
+	unknown := base.EscapeValue{InSet: true, InvDiv: 10}
+	if actual := palette.Color(unknown); actual != black {
+		t.Error("Expected member of unknown period to be black, but was:", actual)
+	}
+
+	one := palette.Color(base.EscapeValue{InSet: true, Period: 1})
+	two := palette.Color(base.EscapeValue{InSet: true, Period: 2})
+	if one == black || one == two {
+		t.Error("Expected distinct colours for periods 1 and 2, but received:", one, two)
+	}
+
+	outside := base.EscapeValue{InvDiv: 0, Period: 1}
+	expect := color.NRGBA{R: 255, G: 0, B: 0, A: 255}
+	if actual := palette.Color(outside); actual != expect {
+		t.Error("Expected", expect, "for escaping point, but received:", actual)
+	}
+}
+
+func TestInteriorDistancePalette(t *testing.T) {
+	palette := NewInteriorDistancePalette(NewPeriodPalette(NewRedscalePalette(10)))
+
+	lit := periodColor(1)
+	deep := palette.Color(base.EscapeValue{InSet: true, Period: 1, Distance: 1000})
+	if deep != lit {
+		t.Error("Expected", lit, "far from the boundary, but received:", deep)
+	}
+
+	edge := palette.Color(base.EscapeValue{InSet: true, Period: 1, Distance: 0.01})
+	if edge.R >= lit.R && edge.G >= lit.G && edge.B >= lit.B {
+		t.Error("Expected darker colour near the boundary, but received:", edge)
+	}
+}
@@ -9,14 +9,16 @@ import (
 type MockDrawingContext struct {
 	TPicture bool
 	TColors  bool
+	TEscapes bool
 
 	Pic *image.NRGBA
 	Col Palette
+	Esc *EscapeMap
 }
 
 var _ DrawingContext = (*MockDrawingContext)(nil)
 
-func NewMockDrawingContext(iterateLimit uint8) *MockDrawingContext {
+func NewMockDrawingContext(iterateLimit uint32) *MockDrawingContext {
 	return &MockDrawingContext{
 		Pic: image.NewNRGBA(image.ZR),
 		Col: NewRedscalePalette(iterateLimit),
@@ -33,6 +35,11 @@ func (mock *MockDrawingContext) Colors() Palette {
 	return mock.Col
 }
 
+func (mock *MockDrawingContext) Escapes() *EscapeMap {
+	mock.TEscapes = true
+	return mock.Esc
+}
+
 type MockPalette struct {
 	TColor bool
 	Col    color.NRGBA
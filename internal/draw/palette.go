@@ -9,4 +9,24 @@ type Palette interface {
 	Color(point base.EscapeValue) color.NRGBA
 }
 
-type PaletteFactory func(interateLimit uint8) Palette
+type PaletteFactory func(iterateLimit uint32) Palette
+
+// Interpolator is a Palette that can also colour the continuous escape value of a point
+type Interpolator interface {
+	Palette
+	Interpolate(point base.EscapeValue) color.NRGBA
+}
+
+// SmoothPalette colours points by their continuous escape value, avoiding colour bands
+type SmoothPalette struct {
+	Interpolator
+}
+
+func NewSmoothPalette(palette Interpolator) Palette {
+	return SmoothPalette{palette}
+}
+
+// SmoothPalette implements Palette
+func (smooth SmoothPalette) Color(point base.EscapeValue) color.NRGBA {
+	return smooth.Interpolate(point)
+}
@@ -8,7 +8,7 @@ type PrettyPalette struct {
 	CachePalette
 }
 
-func NewPrettyPalette(iterateLimit uint8) Palette {
+func NewPrettyPalette(iterateLimit uint32) Palette {
 	black := color.NRGBA{
 		R: 0, G: 0, B: 0, A: 255,
 	}
@@ -18,7 +18,7 @@ func NewPrettyPalette(iterateLimit uint8) Palette {
 }
 
 // Cache redscale colour values
-func prettyCacher(limit uint8, index uint8) color.NRGBA {
+func prettyCacher(limit uint32, index uint32) color.NRGBA {
 	limitF := float64(limit)
 	linear := float64(limit - index)
 	// I made an arthrimetic error when defining this palette apropos a limit of 255.
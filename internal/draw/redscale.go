@@ -8,7 +8,7 @@ type RedscalePalette struct {
 	CachePalette
 }
 
-func NewRedscalePalette(iterateLimit uint8) Palette {
+func NewRedscalePalette(iterateLimit uint32) Palette {
 	black := color.NRGBA{
 		R: 0, G: 0, B: 0, A: 255,
 	}
@@ -18,7 +18,7 @@ func NewRedscalePalette(iterateLimit uint8) Palette {
 }
 
 // Cache redscale colour values
-func redscaleCacher(limit uint8, index uint8) color.NRGBA {
+func redscaleCacher(limit uint32, index uint32) color.NRGBA {
 	calibIndex := float64(limit - index)
 	interval := 255.0 / float64(limit)
 	return color.NRGBA{
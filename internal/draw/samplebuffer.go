@@ -0,0 +1,73 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"image/color"
+	"math"
+)
+
+// SampleBuffer averages several samples of each pixel.  Colours are averaged in linear light,
+// so that edges are not darkened, as they would be by averaging sRGB values.  Each colour is
+// weighted by its alpha, so that transparent samples do not darken the opaque ones.
+type SampleBuffer struct {
+	pixels []image.Point
+	sums   [][4]float64
+	count  int
+}
+
+// Add colours a sample of each pixel.  Every call must be given the same pixels in the same
+// order.
+func (buf *SampleBuffer) Add(palette Palette, members []base.PixelMember) {
+	if buf.pixels == nil {
+		buf.pixels = make([]image.Point, len(members))
+		buf.sums = make([][4]float64, len(members))
+		for i, pix := range members {
+			buf.pixels[i] = image.Pt(pix.I, pix.J)
+		}
+	}
+
+	for i, pix := range members {
+		col := palette.Color(pix.Member)
+		alpha := float64(col.A) / 255.0
+		sum := &buf.sums[i]
+		sum[0] += linear(col.R) * alpha
+		sum[1] += linear(col.G) * alpha
+		sum[2] += linear(col.B) * alpha
+		sum[3] += alpha
+	}
+	buf.count++
+}
+
+// Draw the average of the samples on to the picture
+func (buf *SampleBuffer) Draw(picture *image.NRGBA) {
+	if buf.count == 0 {
+		return
+	}
+
+	n := float64(buf.count)
+	for i, pt := range buf.pixels {
+		sum := buf.sums[i]
+		// Fully transparent pixels have no colour
+		if sum[3] == 0 {
+			picture.SetNRGBA(pt.X, pt.Y, color.NRGBA{})
+			continue
+		}
+		picture.SetNRGBA(pt.X, pt.Y, color.NRGBA{
+			R: nonlinear(sum[0] / sum[3]),
+			G: nonlinear(sum[1] / sum[3]),
+			B: nonlinear(sum[2] / sum[3]),
+			A: uint8(math.Floor((255.0 * sum[3] / n) + 0.5)),
+		})
+	}
+}
+
+// linear converts an sRGB component to linear light in [0, 1]
+func linear(c uint8) float64 {
+	return srgbToLinear(float64(c) / 255.0)
+}
+
+// nonlinear converts linear light in [0, 1] back to an sRGB component
+func nonlinear(x float64) uint8 {
+	return component(linearToSRGB(x))
+}
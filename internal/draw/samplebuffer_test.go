@@ -0,0 +1,67 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestSampleBuffer(t *testing.T) {
+	members := []base.PixelMember{{I: 1, J: 0}}
+	black := &MockPalette{Col: color.NRGBA{0, 0, 0, 255}}
+	white := &MockPalette{Col: color.NRGBA{255, 255, 255, 255}}
+
+	buf := SampleBuffer{}
+	buf.Add(black, members)
+	buf.Add(white, members)
+
+	pic := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	buf.Draw(pic)
+
+	// Half of white in linear light is lighter than half of white in sRGB
+	expect := color.NRGBA{188, 188, 188, 255}
+	if actual := pic.NRGBAAt(1, 0); actual != expect {
+		t.Error("Expected average", expect, "but received:", actual)
+	}
+
+	if untouched := pic.NRGBAAt(0, 0); untouched != (color.NRGBA{}) {
+		t.Error("Expected unsampled pixel to be untouched, but received:", untouched)
+	}
+}
+
+func TestSampleBufferAlpha(t *testing.T) {
+	members := []base.PixelMember{{I: 0, J: 0}}
+	white := &MockPalette{Col: color.NRGBA{255, 255, 255, 255}}
+	transparent := &MockPalette{Col: color.NRGBA{0, 0, 0, 0}}
+
+	buf := SampleBuffer{}
+	buf.Add(white, members)
+	buf.Add(transparent, members)
+
+	pic := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	buf.Draw(pic)
+
+	// A transparent sample thins the colour without darkening it
+	expect := color.NRGBA{255, 255, 255, 128}
+	if actual := pic.NRGBAAt(0, 0); actual != expect {
+		t.Error("Expected average", expect, "but received:", actual)
+	}
+
+	buf = SampleBuffer{}
+	buf.Add(transparent, members)
+	buf.Add(transparent, members)
+	buf.Draw(pic)
+
+	if actual := pic.NRGBAAt(0, 0); actual != (color.NRGBA{}) {
+		t.Error("Expected transparent average, but received:", actual)
+	}
+}
+
+func TestLinearRoundTrip(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		if actual := nonlinear(linear(uint8(c))); actual != uint8(c) {
+			t.Error("Expected component", c, "to survive conversion, but received:", actual)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"math"
+	"math/cmplx"
+)
+
+// DistancePalette darkens points close to the boundary of the set, so that thin filaments
+// stand out against the exterior.
+type DistancePalette struct {
+	Palette
+	// Distance in pixels beyond which points are not darkened
+	Falloff float64
+}
+
+func NewDistancePalette(palette Palette) Palette {
+	return DistancePalette{Palette: palette, Falloff: 4.0}
+}
+
+// DistancePalette implements Palette
+func (dp DistancePalette) Color(point base.EscapeValue) color.NRGBA {
+	col := dp.Palette.Color(point)
+	if point.InSet {
+		return col
+	}
+
+	t := math.Pow(math.Min(1.0, point.Distance/dp.Falloff), 0.25)
+	return shade(col, t)
+}
+
+// ReliefPalette lights the escape value as though it were a surface, using the normal
+// computed alongside the distance estimate.
+type ReliefPalette struct {
+	Palette
+	// Unit vector pointing toward the light
+	Light complex128
+	// Height of the light above the plane
+	Height float64
+}
+
+func NewReliefPalette(palette Palette) Palette {
+	return ReliefPalette{
+		Palette: palette,
+		Light:   cmplx.Rect(1.0, math.Pi/4.0),
+		Height:  1.5,
+	}
+}
+
+// ReliefPalette implements Palette
+func (rp ReliefPalette) Color(point base.EscapeValue) color.NRGBA {
+	col := rp.Palette.Color(point)
+	if point.InSet {
+		return col
+	}
+
+	lit := real(point.Normal * cmplx.Conj(rp.Light))
+	t := (lit + rp.Height) / (1.0 + rp.Height)
+	return shade(col, math.Max(0.0, math.Min(1.0, t)))
+}
+
+// shade scales the brightness of a colour by t in [0, 1]
+func shade(col color.NRGBA, t float64) color.NRGBA {
+	return color.NRGBA{
+		R: uint8(float64(col.R) * t),
+		G: uint8(float64(col.G) * t),
+		B: uint8(float64(col.B) * t),
+		A: col.A,
+	}
+}
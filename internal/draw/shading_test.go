@@ -0,0 +1,51 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"testing"
+)
+
+func TestDistancePalette(t *testing.T) {
+	white := color.NRGBA{255, 255, 255, 255}
+	cacher := func(limit, index uint32) color.NRGBA {
+		return white
+	}
+	palette := NewDistancePalette(NewCachePalette(10, white, cacher))
+
+	far := base.EscapeValue{InvDiv: 2, Distance: 10}
+	if actual := palette.Color(far); actual != white {
+		t.Error("Expected distant point to keep its color, but was:", actual)
+	}
+
+	near := base.EscapeValue{InvDiv: 2, Distance: 0}
+	expect := color.NRGBA{0, 0, 0, 255}
+	if actual := palette.Color(near); actual != expect {
+		t.Error("Expected", expect, "but boundary point was:", actual)
+	}
+
+	inSet := base.EscapeValue{InSet: true}
+	if actual := palette.Color(inSet); actual != white {
+		t.Error("Expected set member to keep its color, but was:", actual)
+	}
+}
+
+func TestReliefPalette(t *testing.T) {
+	white := color.NRGBA{255, 255, 255, 255}
+	cacher := func(limit, index uint32) color.NRGBA {
+		return white
+	}
+	palette := NewReliefPalette(NewCachePalette(10, white, cacher)).(ReliefPalette)
+
+	lit := base.EscapeValue{InvDiv: 2, Normal: palette.Light}
+	if actual := palette.Color(lit); actual != white {
+		t.Error("Expected point facing the light to be fully lit, but was:", actual)
+	}
+
+	away := base.EscapeValue{InvDiv: 2, Normal: -palette.Light}
+	litcol := palette.Color(lit)
+	awaycol := palette.Color(away)
+	if awaycol.R >= litcol.R {
+		t.Error("Expected point facing away from the light to be darker, but was:", awaycol)
+	}
+}
@@ -0,0 +1,44 @@
+package draw
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image/color"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	const iterLimit uint32 = 10
+	cacher := func(iterLimit, index uint32) color.NRGBA {
+		c := uint8(index * 10)
+		return color.NRGBA{c, c, c, 255}
+	}
+	white := color.NRGBA{255, 255, 255, 255}
+	palette := NewCachePalette(iterLimit, white, cacher)
+
+	inSet := base.EscapeValue{InSet: true, Smooth: 10}
+	if actual := palette.Interpolate(inSet); actual != white {
+		t.Error("Expected white, but set member was assigned color:", actual)
+	}
+
+	half := base.EscapeValue{InvDiv: 4, Smooth: 4.5}
+	expect := color.NRGBA{45, 45, 45, 255}
+	if actual := palette.Interpolate(half); actual != expect {
+		t.Error("Expected", expect, "but member was assigned color:", actual)
+	}
+
+	beyond := base.EscapeValue{InvDiv: 9, Smooth: 10.4}
+	expect = color.NRGBA{90, 90, 90, 255}
+	if actual := palette.Interpolate(beyond); actual != expect {
+		t.Error("Expected", expect, "but member was assigned color:", actual)
+	}
+}
+
+func TestSmoothPalette(t *testing.T) {
+	smooth := NewSmoothPalette(NewRedscalePalette(255).(Interpolator))
+	member := base.EscapeValue{InvDiv: 0, Smooth: 0.5}
+	expected := color.NRGBA{R: 255, G: 0, B: 0, A: 255}
+	actual := smooth.Color(member)
+	if expected != actual {
+		t.Error("Expected: ", expected, " Actual: ", actual)
+	}
+}
@@ -0,0 +1,127 @@
+package fixbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"math"
+	"math/big"
+)
+
+// Basis for all fixed-point numerics
+type FixedBaseNumerics struct {
+	base.BaseNumerics
+
+	RealMin big.Int
+	RealMax big.Int
+	ImagMin big.Int
+	ImagMax big.Int
+
+	SqrtDivergeLimit big.Int
+	IterateLimit     uint32
+
+	Runit big.Int
+	Iunit big.Int
+
+	Scale uint
+
+	// Julia set constant, or nil for the Mandelbrot set
+	JuliaSeed *FixedComplex
+}
+
+func Make(app RenderApplication) FixedBaseNumerics {
+	scale := app.Scale()
+	planeMin, planeMax := app.FixedUserCoords()
+	pictureWidth, pictureHeight := app.PictureDimensions()
+	baseConfig := app.BaseConfig()
+
+	fbn := FixedBaseNumerics{
+		BaseNumerics:     base.Make(app),
+		SqrtDivergeLimit: MakeFixed(math.Sqrt(baseConfig.DivergeLimit), scale),
+		IterateLimit:     baseConfig.IterateLimit,
+		Scale:            scale,
+	}
+
+	fbn.RealMin.Set(planeMin.Real())
+	fbn.RealMax.Set(planeMax.Real())
+	fbn.ImagMin.Set(planeMin.Imag())
+	fbn.ImagMax.Set(planeMax.Imag())
+
+	fbn.Runit.Sub(&fbn.RealMax, &fbn.RealMin)
+	fbn.Runit.Quo(&fbn.Runit, big.NewInt(int64(pictureWidth)))
+	fbn.Iunit.Sub(&fbn.ImagMax, &fbn.ImagMin)
+	fbn.Iunit.Quo(&fbn.Iunit, big.NewInt(int64(pictureHeight)))
+
+	if baseConfig.Julia {
+		fbn.JuliaSeed = &FixedComplex{}
+		fbn.JuliaSeed.Set(app.FixedJuliaSeed())
+	}
+
+	return fbn
+}
+
+func (fbn *FixedBaseNumerics) MakeMember(c *FixedComplex) FixedEscapeValue {
+	return FixedEscapeValue{
+		C:                c,
+		SqrtDivergeLimit: &fbn.SqrtDivergeLimit,
+		Scale:            fbn.Scale,
+		Seed:             fbn.JuliaSeed,
+	}
+}
+
+// Size on the plane of 1px
+func (fbn *FixedBaseNumerics) PixelSize() (*big.Int, *big.Int) {
+	return &fbn.Runit, &fbn.Iunit
+}
+
+func (fbn *FixedBaseNumerics) PixelToPlane(i, j int) FixedComplex {
+	c := FixedComplex{}
+	c.R.Mul(&fbn.Runit, big.NewInt(int64(i)))
+	c.R.Add(&c.R, &fbn.RealMin)
+	c.I.Mul(&fbn.Iunit, big.NewInt(int64(j)))
+	c.I.Sub(&fbn.ImagMax, &c.I)
+	return c
+}
+
+func (fbn *FixedBaseNumerics) Escape(c *FixedComplex) FixedEscapeValue {
+	point := fbn.MakeMember(c)
+	point.Mandelbrot(fbn.IterateLimit)
+	return point
+}
+
+func (fbn *FixedBaseNumerics) SubImage(rect image.Rectangle) {
+	topLeft := fbn.PixelToPlane(rect.Min.X, rect.Min.Y)
+	bottomRight := fbn.PixelToPlane(rect.Max.X, rect.Max.Y)
+
+	fbn.PictureSubImage(rect)
+
+	// Replace rather than Set, as copies of the numerics may share the old values
+	fbn.RealMin = topLeft.R
+	fbn.ImagMax = topLeft.I
+	fbn.RealMax = bottomRight.R
+	fbn.ImagMin = bottomRight.I
+}
+
+// Fraction returns the fraction x of a unit, such as the size of a pixel
+func Fraction(unit *big.Int, x float64) *big.Int {
+	f := new(big.Float).SetInt(unit)
+	f.Mul(f, big.NewFloat(x))
+	n, _ := f.Int(nil)
+	return n
+}
+
+// Offset moves the sample point of every pixel by a fraction of a pixel
+func (fbn *FixedBaseNumerics) Offset(dx, dy float64) {
+	r := Fraction(&fbn.Runit, dx)
+	i := Fraction(&fbn.Iunit, dy)
+
+	// Replace rather than Set, as copies of the numerics may share the old values
+	var rmin, rmax, imin, imax big.Int
+	rmin.Add(&fbn.RealMin, r)
+	rmax.Add(&fbn.RealMax, r)
+	imin.Sub(&fbn.ImagMin, i)
+	imax.Sub(&fbn.ImagMax, i)
+	fbn.RealMin = rmin
+	fbn.RealMax = rmax
+	fbn.ImagMin = imin
+	fbn.ImagMax = imax
+}
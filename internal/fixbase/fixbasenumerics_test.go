@@ -0,0 +1,63 @@
+package fixbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
+	"math/big"
+	"testing"
+)
+
+const testScale uint = 60
+
+func makeMock() *MockRenderApplication {
+	mock := &MockRenderApplication{}
+	mock.Base = base.BaseConfig{DivergeLimit: 4.0, IterateLimit: 100}
+	mock.PictureWidth = 100
+	mock.PictureHeight = 50
+	mock.FracScale = testScale
+	mock.UserMin = MakeFixedComplex(-2.0, -1.0, testScale)
+	mock.UserMax = MakeFixedComplex(2.0, 1.0, testScale)
+	return mock
+}
+
+func TestMake(t *testing.T) {
+	mock := makeMock()
+	numerics := Make(mock)
+
+	if !(mock.TFixedUserCoords && mock.TScale && mock.TPictureDimensions && mock.TBaseConfig) {
+		t.Error("Expected methods not called on mock", mock)
+	}
+
+	rUnit, iUnit := numerics.PixelSize()
+	expect := MakeFixed(4.0, testScale)
+	expect.Quo(&expect, big.NewInt(100))
+	if rUnit.Cmp(&expect) != 0 || iUnit.Cmp(&expect) != 0 {
+		t.Error("Expected pixel size", &expect, "but received", rUnit, iUnit)
+	}
+
+	limit := MakeFixed(2.0, testScale)
+	if numerics.SqrtDivergeLimit.Cmp(&limit) != 0 {
+		t.Error("Expected SqrtDivergeLimit", &limit, "but received", &numerics.SqrtDivergeLimit)
+	}
+}
+
+func TestSubImage(t *testing.T) {
+	numerics := Make(makeMock())
+	numerics.SubImage(image.Rect(25, 0, 50, 25))
+
+	actual := []float64{
+		Float64(&numerics.RealMin, testScale),
+		Float64(&numerics.RealMax, testScale),
+		Float64(&numerics.ImagMin, testScale),
+		Float64(&numerics.ImagMax, testScale),
+	}
+	expect := []float64{-1.0, 0.0, 0.0, 1.0}
+
+	for i, ex := range expect {
+		// Pixel size is truncated, so allow for a little error
+		if diff := actual[i] - ex; diff > 1e-15 || diff < -1e-15 {
+			t.Error("Expected plane", expect, "after SubImage but received", actual)
+			break
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package fixbase
+
+import (
+	"math/big"
+)
+
+// FixedComplex is a complex number whose parts are fixed-point numbers.  A fixed-point number
+// n with scale s represents n / 2^s.
+type FixedComplex struct {
+	R big.Int
+	I big.Int
+}
+
+func (c *FixedComplex) Real() *big.Int {
+	return &c.R
+}
+
+func (c *FixedComplex) Imag() *big.Int {
+	return &c.I
+}
+
+func (c *FixedComplex) Set(u *FixedComplex) {
+	c.R.Set(&u.R)
+	c.I.Set(&u.I)
+}
+
+// Native approximation of the complex number
+func (c *FixedComplex) Complex128(scale uint) complex128 {
+	return complex(Float64(&c.R, scale), Float64(&c.I, scale))
+}
+
+func MakeFixedComplex(r, i float64, scale uint) FixedComplex {
+	return FixedComplex{MakeFixed(r, scale), MakeFixed(i, scale)}
+}
+
+func MakeFixed(x float64, scale uint) big.Int {
+	return FromBig(big.NewFloat(x), scale)
+}
+
+// FromBig converts a big.Float into a fixed-point number, truncating bits beyond the scale
+func FromBig(x *big.Float, scale uint) big.Int {
+	shifted := new(big.Float).SetMantExp(x, int(scale))
+	n := big.Int{}
+	shifted.Int(&n)
+	return n
+}
+
+// ToBig converts a fixed-point number into a big.Float
+func ToBig(n *big.Int, scale uint, prec uint) *big.Float {
+	x := new(big.Float).SetPrec(prec).SetInt(n)
+	return x.SetMantExp(x, -int(scale))
+}
+
+func Float64(n *big.Int, scale uint) float64 {
+	f, _ := ToBig(n, scale, 64).Float64()
+	return f
+}
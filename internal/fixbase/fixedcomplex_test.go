@@ -0,0 +1,23 @@
+package fixbase
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFromBig(t *testing.T) {
+	const scale uint = 100
+	x := big.NewFloat(-1.5)
+
+	n := FromBig(x, scale)
+
+	expect := new(big.Int).Lsh(big.NewInt(-3), scale-1)
+	if n.Cmp(expect) != 0 {
+		t.Error("Expected", expect, "but received", &n)
+	}
+
+	back := ToBig(&n, scale, 53)
+	if back.Cmp(x) != 0 {
+		t.Error("Expected round trip to", x, "but received", back)
+	}
+}
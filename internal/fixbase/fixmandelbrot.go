@@ -0,0 +1,66 @@
+package fixbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math/big"
+	"math/cmplx"
+)
+
+type FixedEscapeValue struct {
+	base.EscapeValue
+	// Point on the plane
+	C                *FixedComplex
+	SqrtDivergeLimit *big.Int
+	Scale            uint
+	// Julia set constant, or nil for the Mandelbrot set
+	Seed *FixedComplex
+}
+
+func (member *FixedEscapeValue) Mandelbrot(iterateLimit uint32) {
+	scale := member.Scale
+	z := FixedComplex{}
+	c := member.C
+	if member.Seed != nil {
+		z.Set(member.C)
+		c = member.Seed
+	}
+	aa := big.Int{}
+	bb := big.Int{}
+	ab := big.Int{}
+	step := func() {
+		aa.Mul(&z.R, &z.R)
+		aa.Rsh(&aa, scale)
+		bb.Mul(&z.I, &z.I)
+		bb.Rsh(&bb, scale)
+		// Doubling is folded into the shift
+		ab.Mul(&z.R, &z.I)
+		ab.Rsh(&ab, scale-1)
+
+		z.R.Sub(&aa, &bb)
+		z.R.Add(&z.R, &c.R)
+		z.I.Add(&ab, &c.I)
+	}
+
+	i := uint32(0)
+	for ; i < iterateLimit && withinMandLimit(&z, member.SqrtDivergeLimit); i++ {
+		step()
+	}
+
+	member.InSet = i >= iterateLimit
+	member.InvDiv = i
+
+	if member.InSet {
+		member.Smooth = float64(iterateLimit)
+		return
+	}
+
+	for k := uint32(0); k < base.SmoothIterations; k++ {
+		step()
+	}
+	member.Smooth = base.SmoothEscape(i+base.SmoothIterations, cmplx.Abs(z.Complex128(scale)))
+}
+
+func withinMandLimit(z *FixedComplex, limit *big.Int) bool {
+	// Approximate cmplx.Abs
+	return z.R.CmpAbs(limit) == -1 && z.I.CmpAbs(limit) == -1
+}
@@ -0,0 +1,61 @@
+package fixbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"math/big"
+	"testing"
+)
+
+func TestMandelbrotSanity(t *testing.T) {
+	const scale uint = 60
+	const iterateLimit uint32 = 255
+
+	origin := MakeFixedComplex(0, 0, scale)
+	non := MakeFixedComplex(2, 4, scale)
+	sqrtDivergeLimit := MakeFixed(2, scale)
+
+	originMember := FixedEscapeValue{C: &origin, SqrtDivergeLimit: &sqrtDivergeLimit, Scale: scale}
+	nonMember := FixedEscapeValue{C: &non, SqrtDivergeLimit: &sqrtDivergeLimit, Scale: scale}
+
+	originMember.Mandelbrot(iterateLimit)
+	nonMember.Mandelbrot(iterateLimit)
+
+	if !originMember.InSet {
+		t.Error("Expected origin to be in Mandelbrot set")
+	}
+
+	if nonMember.InSet {
+		t.Error("Expected ", nonMember, " to be outside Mandelbrot set")
+	}
+}
+
+func TestMandelbrotMatchesBig(t *testing.T) {
+	const prec uint = 100
+	const iterateLimit uint32 = 5000
+
+	// Points near the boundary which escape after thousands of iterations
+	re := big.NewFloat(-7436438870371587).SetPrec(prec)
+	re.Quo(re, big.NewFloat(1e16))
+	im := big.NewFloat(1318259042053119).SetPrec(prec)
+	im.Quo(im, big.NewFloat(1e16))
+	nudge := big.NewFloat(1e-12)
+
+	bigLimit := bigbase.MakeBigFloat(2, prec)
+	fixLimit := MakeFixed(2, prec)
+
+	for i := 1; i < 10; i++ {
+		r := new(big.Float).SetPrec(prec).Add(re, new(big.Float).Mul(nudge, big.NewFloat(float64(i))))
+		bigc := bigbase.BigComplex{R: *r, I: *new(big.Float).SetPrec(prec).Set(im)}
+		fixc := FixedComplex{R: FromBig(r, prec), I: FromBig(im, prec)}
+
+		bigMember := bigbase.BigEscapeValue{C: &bigc, Prec: prec, SqrtDivergeLimit: &bigLimit}
+		fixMember := FixedEscapeValue{C: &fixc, Scale: prec, SqrtDivergeLimit: &fixLimit}
+
+		bigMember.Mandelbrot(iterateLimit)
+		fixMember.Mandelbrot(iterateLimit)
+
+		if bigMember.InvDiv != fixMember.InvDiv {
+			t.Error("At", r, "expected", bigMember.InvDiv, "but received", fixMember.InvDiv)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package fixbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+)
+
+type MockRenderApplication struct {
+	base.MockRenderApplication
+	MockFixedCoordProvider
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
+
+type MockFixedCoordProvider struct {
+	TFixedUserCoords bool
+	TFixedJuliaSeed  bool
+	TScale           bool
+
+	UserMin   FixedComplex
+	UserMax   FixedComplex
+	JuliaSeed FixedComplex
+	FracScale uint
+}
+
+func (mock *MockFixedCoordProvider) FixedUserCoords() (*FixedComplex, *FixedComplex) {
+	mock.TFixedUserCoords = true
+	return &mock.UserMin, &mock.UserMax
+}
+
+func (mock *MockFixedCoordProvider) Scale() uint {
+	mock.TScale = true
+	return mock.FracScale
+}
+
+func (mock *MockFixedCoordProvider) FixedJuliaSeed() *FixedComplex {
+	mock.TFixedJuliaSeed = true
+	return &mock.JuliaSeed
+}
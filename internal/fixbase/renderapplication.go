@@ -0,0 +1,17 @@
+package fixbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+)
+
+type FixedCoordProvider interface {
+	FixedUserCoords() (*FixedComplex, *FixedComplex)
+	FixedJuliaSeed() *FixedComplex
+	// Number of fractional bits in each fixed-point number
+	Scale() uint
+}
+
+type RenderApplication interface {
+	base.RenderApplication
+	FixedCoordProvider
+}
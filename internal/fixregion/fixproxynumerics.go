@@ -0,0 +1,49 @@
+package fixregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+	"github.com/johnny-morrice/godelbrot/internal/fixsequence"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type FixedRegionProxy struct {
+	*FixedRegionNumerics
+	LocalRegion fixRegion
+}
+
+// Check we implement the interface
+var _ region.RegionNumerics = FixedRegionProxy{}
+
+func (proxy FixedRegionProxy) ClaimExtrinsics() {
+	proxy.FixedRegionNumerics.Region = proxy.LocalRegion
+}
+
+func (proxy FixedRegionProxy) Extrinsically(f func()) {
+	old := proxy.FixedRegionNumerics.Region
+	proxy.ClaimExtrinsics()
+	f()
+	proxy.FixedRegionNumerics.Region = old
+}
+
+type FixedSequenceProxy struct {
+	*fixsequence.FixedSequenceNumerics
+	LocalRegion fixRegion
+}
+
+func (proxy FixedSequenceProxy) ClaimExtrinsics() {
+	proxy.FixedSequenceNumerics.SubImage(proxy.LocalRegion.rect().Intersect(proxy.TileRect()))
+}
+
+func (proxy FixedSequenceProxy) Extrinsically(f func()) {
+	cmin := fixbase.FixedComplex{R: proxy.RealMin, I: proxy.ImagMin}
+	cmax := fixbase.FixedComplex{R: proxy.RealMax, I: proxy.ImagMax}
+
+	proxy.ClaimExtrinsics()
+	f()
+	proxy.RealMin = cmin.R
+	proxy.ImagMin = cmin.I
+	proxy.RealMax = cmax.R
+	proxy.ImagMax = cmax.I
+
+	proxy.RestorePicBounds()
+}
@@ -0,0 +1,241 @@
+package fixregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+	"github.com/johnny-morrice/godelbrot/internal/fixsequence"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+	"image"
+	"log"
+	"math/big"
+)
+
+type fixSubregion struct {
+	populated bool
+	children  []fixRegion
+}
+
+type fixRegion struct {
+	region.Region
+	topLeft     fixbase.FixedEscapeValue
+	topRight    fixbase.FixedEscapeValue
+	bottomLeft  fixbase.FixedEscapeValue
+	bottomRight fixbase.FixedEscapeValue
+	midPoint    fixbase.FixedEscapeValue
+}
+
+func (fr *fixRegion) rect() image.Rectangle {
+	return image.Rect(fr.Xmin, fr.Ymin, fr.Xmax, fr.Ymax)
+}
+
+// Extend FixedBaseNumerics and add support for regions
+type FixedRegionNumerics struct {
+	region.RegionConfig
+	fixbase.FixedBaseNumerics
+	Region           fixRegion
+	SequenceNumerics *fixsequence.FixedSequenceNumerics
+	subregion        fixSubregion
+}
+
+// Check that we implement the interface
+var _ region.RegionNumerics = (*FixedRegionNumerics)(nil)
+
+func Make(app RenderApplication) FixedRegionNumerics {
+	sequence := fixsequence.Make(app)
+	parent := fixbase.Make(app)
+	reg := FixedRegionNumerics{
+		FixedBaseNumerics: parent,
+		RegionConfig:      app.RegionConfig(),
+		SequenceNumerics:  &sequence,
+	}
+	reg.initRegion()
+	return reg
+}
+
+func (fix *FixedRegionNumerics) ClaimExtrinsics() {
+	// Region already present
+}
+
+func (fix *FixedRegionNumerics) Extrinsically(f func()) {
+	f()
+}
+
+// Return the children of this region
+func (fix *FixedRegionNumerics) Children() []region.RegionNumerics {
+	const childCount = 4
+	if fix.subregion.populated {
+		nextContexts := make([]region.RegionNumerics, childCount)
+		for i, child := range fix.subregion.children {
+			nextContexts[i] = fix.Proxy(child)
+		}
+		return nextContexts
+	}
+	log.Panic("Region asked to provide non-existent children")
+	return nil
+}
+
+func (fix *FixedRegionNumerics) RegionSequence() region.ProxySequence {
+	return fix.FixedSequence()
+}
+
+func (fix *FixedRegionNumerics) FixedSequence() FixedSequenceProxy {
+	numerics := fix.SequenceNumerics
+	if fix.Concurrent {
+		copied := *numerics
+		numerics = &copied
+	}
+	return FixedSequenceProxy{
+		LocalRegion:           fix.Region,
+		FixedSequenceNumerics: numerics,
+	}
+}
+
+func (fix *FixedRegionNumerics) Proxy(region fixRegion) FixedRegionProxy {
+	numerics := fix
+	if fix.Concurrent {
+		copied := *fix
+		copied.subregion = fixSubregion{}
+		numerics = &copied
+	}
+	return FixedRegionProxy{
+		LocalRegion:         region,
+		FixedRegionNumerics: numerics,
+	}
+}
+
+func (fix *FixedRegionNumerics) MandelbrotPoints() []base.EscapeValue {
+	ps := fix.Points()
+	base := make([]base.EscapeValue, len(ps))
+	for i, p := range ps {
+		base[i] = p.EscapeValue
+	}
+	return base
+}
+
+func (fix *FixedRegionNumerics) Split() {
+	imgchlds := fix.Region.Split()
+
+	pchlds := make([]fixRegion, len(imgchlds))
+
+	for i, ic := range imgchlds {
+		pchlds[i] = fix.planeRegion(ic)
+	}
+
+	fix.subregion = fixSubregion{
+		populated: true,
+		children:  pchlds,
+	}
+}
+
+func (fix *FixedRegionNumerics) planeRegion(r region.Region) fixRegion {
+	topLeft := fix.PixelToPlane(r.Xmin, r.Ymin)
+	topRight := fix.PixelToPlane(r.Xmax, r.Ymin)
+	bottomLeft := fix.PixelToPlane(r.Xmin, r.Ymax)
+	bottomRight := fix.PixelToPlane(r.Xmax, r.Ymax)
+
+	midPoint := fixbase.FixedComplex{}
+	midPoint.R.Add(topLeft.Real(), bottomRight.Real())
+	midPoint.R.Rsh(&midPoint.R, 1)
+	midPoint.I.Add(topLeft.Imag(), bottomRight.Imag())
+	midPoint.I.Rsh(&midPoint.I, 1)
+
+	freg := fixRegion{}
+	freg.topLeft = fix.Escape(&topLeft)
+	freg.topRight = fix.Escape(&topRight)
+	freg.bottomLeft = fix.Escape(&bottomLeft)
+	freg.bottomRight = fix.Escape(&bottomRight)
+	freg.midPoint = fix.Escape(&midPoint)
+	freg.Region = r
+
+	return freg
+}
+
+func (fix *FixedRegionNumerics) Rect() image.Rectangle {
+	return fix.Region.rect()
+}
+
+// Return EscapeValue
+// Does not check if the region's Points have been evaluated
+func (fix *FixedRegionNumerics) RegionMember() base.EscapeValue {
+	return fix.Region.topLeft.EscapeValue
+}
+
+func (fix *FixedRegionNumerics) Points() []fixbase.FixedEscapeValue {
+	region := fix.Region
+	return []fixbase.FixedEscapeValue{
+		region.topLeft,
+		region.topRight,
+		region.bottomLeft,
+		region.bottomRight,
+		region.midPoint,
+	}
+}
+
+func (fix *FixedRegionNumerics) SampleDivs() (<-chan uint32, chan<- bool) {
+	done := make(chan bool, 1)
+	idivch := make(chan uint32)
+
+	go fix.sample(idivch, done)
+
+	return idivch, done
+}
+
+func (fix *FixedRegionNumerics) sample(idivch chan<- uint32, done <-chan bool) {
+	complete := func(idiv uint32) bool {
+		select {
+		case <-done:
+			close(idivch)
+			return true
+		default:
+			idivch <- idiv
+			return false
+		}
+	}
+
+	eval := func(r, i *big.Int) uint32 {
+		c := fixbase.FixedComplex{}
+		c.R.Set(r)
+		c.I.Set(i)
+		p := fix.Escape(&c)
+		return p.InvDiv
+	}
+
+	// Provide the samples we already have
+	for _, p := range fix.Points() {
+		if complete(p.InvDiv) {
+			return
+		}
+	}
+
+	// Generate samples
+	tl := fix.Region.topLeft.C
+	br := fix.Region.bottomRight.C
+	count := fix.Samples
+	bigCount := big.NewInt(int64(count))
+	rUnit := big.Int{}
+	rUnit.Sub(br.Real(), tl.Real())
+	rUnit.Quo(&rUnit, bigCount)
+	iUnit := big.Int{}
+	iUnit.Sub(tl.Imag(), br.Imag())
+	iUnit.Quo(&iUnit, bigCount)
+	rdown := big.Int{}
+	rdown.Set(br.Real())
+	idown := big.Int{}
+	for i := uint(0); i < count; i++ {
+		rdown.Sub(&rdown, &rUnit)
+		idown.Set(tl.Imag())
+		for j := uint(0); j < count; j++ {
+			idown.Sub(&idown, &iUnit)
+			if complete(eval(&rdown, &idown)) {
+				return
+			}
+		}
+	}
+	close(idivch)
+}
+
+func (fix *FixedRegionNumerics) initRegion() {
+	reg := region.InitRegion(&fix.BaseNumerics)
+
+	fix.Region = fix.planeRegion(reg)
+}
@@ -0,0 +1,15 @@
+package fixregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type MockRenderApplication struct {
+	fixbase.MockFixedCoordProvider
+	region.MockRegionProvider
+	base.MockRenderApplication
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
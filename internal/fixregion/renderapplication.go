@@ -0,0 +1,13 @@
+package fixregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type RenderApplication interface {
+	fixbase.FixedCoordProvider
+	region.RegionProvider
+	base.RenderApplication
+}
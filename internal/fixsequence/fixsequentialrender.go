@@ -0,0 +1,65 @@
+package fixsequence
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+	"github.com/johnny-morrice/godelbrot/internal/sequence"
+)
+
+type FixedSequenceNumerics struct {
+	fixbase.FixedBaseNumerics
+}
+
+// Check that FixedSequenceNumerics implements SequenceNumerics interface
+var _ sequence.SequenceNumerics = (*FixedSequenceNumerics)(nil)
+
+func Make(app fixbase.RenderApplication) FixedSequenceNumerics {
+	return FixedSequenceNumerics{
+		FixedBaseNumerics: fixbase.Make(app),
+	}
+}
+
+func (fsn *FixedSequenceNumerics) Sequence() []base.PixelMember {
+	ileft, itop := fsn.PictureMin()
+	iright, ibott := fsn.PictureMax()
+	iterlim := fsn.IterateLimit
+
+	area := (iright - ileft) * (ibott - itop)
+	out := make([]base.PixelMember, area)
+
+	pos := fixbase.FixedComplex{}
+	pos.R.Set(&fsn.RealMin)
+	count := 0
+	// Jittered samples are taken from a copy of the position
+	jitter := fsn.Jitter.Cell != 0
+	jittered := fixbase.FixedComplex{}
+	member := fsn.MakeMember(&pos)
+	if jitter {
+		member = fsn.MakeMember(&jittered)
+	}
+	for i := ileft; i < iright; i++ {
+		if fsn.Cancelled() {
+			break
+		}
+		column := count
+		pos.I.Set(&fsn.ImagMax)
+		for j := itop; j < ibott; j++ {
+			if fsn.Lattice.Contains(i, j) {
+				if jitter {
+					dx, dy := fsn.Jitter.At(i, j)
+					jittered.R.Add(&pos.R, fixbase.Fraction(&fsn.Runit, dx))
+					jittered.I.Sub(&pos.I, fixbase.Fraction(&fsn.Iunit, dy))
+				}
+				member.Mandelbrot(iterlim)
+				out[count] = base.PixelMember{I: i, J: j, Member: member.EscapeValue}
+				count++
+			}
+
+			pos.I.Sub(&pos.I, &fsn.Iunit)
+		}
+		fsn.Tracker.AddPixels(count - column)
+		pos.R.Add(&pos.R, &fsn.Runit)
+	}
+
+	return out[:count]
+}
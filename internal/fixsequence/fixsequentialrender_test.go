@@ -0,0 +1,72 @@
+package fixsequence
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/bigsequence"
+	"github.com/johnny-morrice/godelbrot/internal/fixbase"
+	"math/big"
+	"testing"
+)
+
+func TestFixedMatchesBigSequence(t *testing.T) {
+	const prec uint = 100
+	const iterLimit = 3000
+
+	baseApp := base.MockRenderApplication{
+		Base:          base.BaseConfig{DivergeLimit: 4.0, IterateLimit: iterLimit},
+		PictureWidth:  20,
+		PictureHeight: 20,
+	}
+
+	// A plane too small for native floats
+	bounds := []string{
+		"-0.743643887037158704752190",
+		"0.131825904205311970493120",
+		"-0.743643887037158704752170",
+		"0.131825904205311970493140",
+	}
+	bigBounds := make([]big.Float, len(bounds))
+	for i, s := range bounds {
+		_, _, err := bigBounds[i].SetPrec(prec).Parse(s, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bigApp := &bigbase.MockRenderApplication{MockRenderApplication: baseApp}
+	bigApp.Prec = prec
+	bigApp.UserMin = bigbase.BigComplex{R: bigBounds[0], I: bigBounds[1]}
+	bigApp.UserMax = bigbase.BigComplex{R: bigBounds[2], I: bigBounds[3]}
+
+	fixApp := &fixbase.MockRenderApplication{MockRenderApplication: baseApp}
+	fixApp.FracScale = prec
+	fixApp.UserMin = fixbase.FixedComplex{
+		R: fixbase.FromBig(&bigBounds[0], prec),
+		I: fixbase.FromBig(&bigBounds[1], prec),
+	}
+	fixApp.UserMax = fixbase.FixedComplex{
+		R: fixbase.FromBig(&bigBounds[2], prec),
+		I: fixbase.FromBig(&bigBounds[3], prec),
+	}
+
+	numerics := Make(fixApp)
+	out := numerics.Sequence()
+
+	bigNumerics := bigsequence.Make(bigApp)
+	expect := bigNumerics.Sequence()
+
+	if len(expect) != len(out) {
+		t.Fatal("Expected", len(expect), "members but there were", len(out))
+	}
+
+	for i, px := range out {
+		ex := expect[i]
+		if px.I != ex.I || px.J != ex.J {
+			t.Fatal("Expected pixel", ex.I, ex.J, "but received", px.I, px.J)
+		}
+		if px.Member.InvDiv != ex.Member.InvDiv {
+			t.Error("At pixel", px.I, px.J, "expected", ex.Member.InvDiv, "but received", px.Member.InvDiv)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package nativebase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math/cmplx"
+)
+
+// Formula is an escape-time iteration, returning the value that follows z in its orbit.
+// Exponent is the power z is raised to, where zero means 2.
+type Formula interface {
+	Iterate(z, c complex128, exponent uint) complex128
+	// Derive returns the derivative of the iterated value, excluding c, given the derivative dz
+	// of z.  Formulae that are not complex differentiable give the derivative along the real
+	// axis.
+	Derive(z, dz complex128, exponent uint) complex128
+}
+
+// MandelbrotFormula iterates z^n + c
+type MandelbrotFormula struct{}
+
+// isMandelbrot is true if formula is nil or the Mandelbrot formula
+func isMandelbrot(formula Formula) bool {
+	_, ok := formula.(MandelbrotFormula)
+	return formula == nil || ok
+}
+
+func (MandelbrotFormula) Iterate(z, c complex128, exponent uint) complex128 {
+	return power(z, exponent) + c
+}
+
+func (MandelbrotFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	return base.PowerDerivative(z, dz, exponent)
+}
+
+// BurningShipFormula iterates (|Re z| + i|Im z|)^n + c
+type BurningShipFormula struct{}
+
+func (BurningShipFormula) Iterate(z, c complex128, exponent uint) complex128 {
+	folded := complex(abs(real(z)), abs(imag(z)))
+	return power(folded, exponent) + c
+}
+
+func (BurningShipFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	folded := complex(abs(real(z)), abs(imag(z)))
+	dfolded := complex(sign(real(z))*real(dz), sign(imag(z))*imag(dz))
+	return base.PowerDerivative(folded, dfolded, exponent)
+}
+
+// TricornFormula iterates conj(z)^n + c.  It is also known as the Mandelbar set.
+type TricornFormula struct{}
+
+func (TricornFormula) Iterate(z, c complex128, exponent uint) complex128 {
+	return power(complex(real(z), -imag(z)), exponent) + c
+}
+
+func (TricornFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	return base.PowerDerivative(cmplx.Conj(z), cmplx.Conj(dz), exponent)
+}
+
+// CelticFormula iterates |Re z^n| + i Im z^n + c
+type CelticFormula struct{}
+
+func (CelticFormula) Iterate(z, c complex128, exponent uint) complex128 {
+	zn := power(z, exponent)
+	return complex(abs(real(zn)), imag(zn)) + c
+}
+
+func (CelticFormula) Derive(z, dz complex128, exponent uint) complex128 {
+	zn := power(z, exponent)
+	dzn := base.PowerDerivative(z, dz, exponent)
+	return complex(sign(real(zn))*real(dzn), imag(dzn))
+}
+
+// power raises z to the nth power by repeated multiplication.  This is faster and more accurate
+// than cmplx.Pow for the small exponents used by Multibrot sets.
+func power(z complex128, n uint) complex128 {
+	if n <= 2 {
+		return z * z
+	}
+	zn := z
+	for k := uint(1); k < n; k++ {
+		zn *= z
+	}
+	return zn
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,56 @@
+package nativebase
+
+import (
+	"testing"
+)
+
+func TestFormulaIterate(t *testing.T) {
+	const z complex128 = -1 - 2i
+	const c complex128 = 0.5 + 0.25i
+
+	expect := map[Formula]complex128{
+		MandelbrotFormula{}:  (z * z) + c,
+		BurningShipFormula{}: ((1 + 2i) * (1 + 2i)) + c,
+		TricornFormula{}:     ((-1 + 2i) * (-1 + 2i)) + c,
+		CelticFormula{}:      complex(3, imag(z*z)) + c,
+	}
+
+	for formula, e := range expect {
+		actual := formula.Iterate(z, c, 2)
+		if actual != e {
+			t.Error("Expected", formula, "to give", e, "but received", actual)
+		}
+	}
+
+	mandelbrot := MandelbrotFormula{}
+
+	// Zero exponent means 2
+	if mandelbrot.Iterate(z, c, 0) != expect[mandelbrot] {
+		t.Error("Expected zero exponent to square z")
+	}
+
+	cube := mandelbrot.Iterate(z, c, 3)
+	if cube != (z*z*z)+c {
+		t.Error("Expected", (z*z*z)+c, "but received", cube)
+	}
+}
+
+func TestFormulaEscape(t *testing.T) {
+	const iterateLimit uint32 = 255
+	const sqrtDivergeLimit float64 = 2
+
+	// -1.75 lies in the main body of the Burning Ship, but -1 + 0.5i escapes from it
+	inside := NativeEscapeValue{C: -1.75, SqrtDivergeLimit: sqrtDivergeLimit, Formula: BurningShipFormula{}}
+	outside := NativeEscapeValue{C: -1 + 0.5i, SqrtDivergeLimit: sqrtDivergeLimit, Formula: BurningShipFormula{}}
+
+	inside.Mandelbrot(iterateLimit)
+	outside.Mandelbrot(iterateLimit)
+
+	if !inside.InSet {
+		t.Error("Expected ", inside, " to be inside Burning Ship")
+	}
+
+	if outside.InSet {
+		t.Error("Expected ", outside, " to be outside Burning Ship")
+	}
+}
@@ -11,12 +11,26 @@ type MockRenderApplication struct {
 
 type MockNativeCoordProvider struct {
 	TNativeUserCoords bool
+	TNativeJuliaSeed  bool
+	TNativeFormula    bool
 
-	PlaneMin complex128
-	PlaneMax complex128
+	PlaneMin  complex128
+	PlaneMax  complex128
+	JuliaSeed complex128
+	Formula   Formula
 }
 
 func (mock *MockNativeCoordProvider) NativeUserCoords() (complex128, complex128) {
 	mock.TNativeUserCoords = true
 	return mock.PlaneMin, mock.PlaneMax
 }
+
+func (mock *MockNativeCoordProvider) NativeJuliaSeed() complex128 {
+	mock.TNativeJuliaSeed = true
+	return mock.JuliaSeed
+}
+
+func (mock *MockNativeCoordProvider) NativeFormula() Formula {
+	mock.TNativeFormula = true
+	return mock.Formula
+}
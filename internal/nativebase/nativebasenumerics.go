@@ -19,7 +19,21 @@ type NativeBaseNumerics struct {
 	Iunit float64
 
 	SqrtDivergeLimit float64
-	IterateLimit     uint8
+	IterateLimit     uint32
+
+	Julia     bool
+	JuliaSeed complex128
+	Exponent  uint
+	Formula   Formula
+
+	CardioidCheck    bool
+	PeriodicityCheck bool
+
+	TrackDerivative bool
+	TrackInterior   bool
+
+	// Top left of the whole picture, which SubImage leaves alone
+	corner complex128
 }
 
 func Make(app RenderApplication) NativeBaseNumerics {
@@ -32,6 +46,9 @@ func Make(app RenderApplication) NativeBaseNumerics {
 	uq := UnitQuery{pictureWidth, pictureHeight, planeWidth, planeHeight}
 	rUnit, iUnit := uq.PixelUnits()
 
+	formula := app.NativeFormula()
+	quadratic := !config.Julia && config.Exponent <= 2 && isMandelbrot(formula)
+
 	return NativeBaseNumerics{
 		BaseNumerics: base.Make(app),
 		RealMin:      real(planeMin),
@@ -44,6 +61,19 @@ func Make(app RenderApplication) NativeBaseNumerics {
 
 		Runit: rUnit,
 		Iunit: iUnit,
+
+		Julia:     config.Julia,
+		JuliaSeed: app.NativeJuliaSeed(),
+		Exponent:  config.Exponent,
+		Formula:   formula,
+
+		CardioidCheck:    config.CardioidCheck && quadratic,
+		PeriodicityCheck: config.PeriodicityCheck,
+
+		TrackDerivative: config.TrackDerivative,
+		TrackInterior:   config.TrackInterior,
+
+		corner: complex(real(planeMin), imag(planeMax)),
 	}
 }
 
@@ -51,6 +81,15 @@ func (nbn *NativeBaseNumerics) CreateMandelbrot(c complex128) NativeEscapeValue
 	return NativeEscapeValue{
 		C:                c,
 		SqrtDivergeLimit: nbn.SqrtDivergeLimit,
+		Julia:            nbn.Julia,
+		Seed:             nbn.JuliaSeed,
+		Exponent:         nbn.Exponent,
+		Formula:          nbn.Formula,
+		CardioidCheck:    nbn.CardioidCheck,
+		PeriodicityCheck: nbn.PeriodicityCheck,
+		TrackDerivative:  nbn.TrackDerivative,
+		PixelSize:        nbn.Runit,
+		TrackInterior:    nbn.TrackInterior,
 	}
 }
 
@@ -86,6 +125,16 @@ func (nbn *NativeBaseNumerics) PixelToPlane(i, j int) complex128 {
 	return complex(tr, ti)
 }
 
+// PixelPosition returns the point sampled by pixel (i, j).  Unlike PixelToPlane, it is measured
+// from the corner of the whole picture, so a pixel samples the same point however the picture
+// is divided by SubImage.
+func (nbn *NativeBaseNumerics) PixelPosition(i, j int) complex128 {
+	dx, dy := nbn.Jitter.At(i, j)
+	x := real(nbn.corner) + ((float64(i) + dx) * nbn.Runit)
+	y := imag(nbn.corner) - ((float64(j) + dy) * nbn.Iunit)
+	return complex(x, y)
+}
+
 func (nbn *NativeBaseNumerics) Xtor(i int) float64 {
 	sr := float64(i) * nbn.Runit
 
@@ -105,15 +154,26 @@ func (nbn *NativeBaseNumerics) Escape(c complex128) NativeEscapeValue {
 }
 
 func (nbn *NativeBaseNumerics) SubImage(rect image.Rectangle) {
-	min := nbn.PixelToPlane(rect.Min.X, rect.Min.Y)
-	max := nbn.PixelToPlane(rect.Max.X, rect.Max.Y)
+	topLeft := nbn.PixelToPlane(rect.Min.X, rect.Min.Y)
+	bottomRight := nbn.PixelToPlane(rect.Max.X, rect.Max.Y)
 
 	nbn.PictureSubImage(rect)
 
-	nbn.RealMin = real(min)
-	nbn.ImagMin = imag(min)
-	nbn.RealMax = real(max)
-	nbn.ImagMax = imag(max)
+	nbn.RealMin = real(topLeft)
+	nbn.ImagMax = imag(topLeft)
+	nbn.RealMax = real(bottomRight)
+	nbn.ImagMin = imag(bottomRight)
+}
+
+// Offset moves the sample point of every pixel by a fraction of a pixel
+func (nbn *NativeBaseNumerics) Offset(dx, dy float64) {
+	r := dx * nbn.Runit
+	i := dy * nbn.Iunit
+	nbn.RealMin += r
+	nbn.RealMax += r
+	nbn.ImagMin -= i
+	nbn.ImagMax -= i
+	nbn.corner += complex(r, -i)
 }
 
 type UnitQuery struct {
@@ -2,11 +2,12 @@ package nativebase
 
 import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
+	"image"
 	"math"
 	"testing"
 )
 
-// Three paths through Make
+// Make keeps the plane whatever the aspect ratio, which the configurator fixes beforehand
 // 1. Aspect ratio is okay
 // 2. Aspect ratio is too short
 // 3. Aspect ratio is too thin
@@ -36,7 +37,7 @@ func TestMake(t *testing.T) {
 		iMax: 0.5,
 
 		expectRMin: -0.5,
-		expectRMax: 1.5,
+		expectRMax: 0.5,
 		expectIMin: -0.5,
 		expectIMax: 0.5,
 	}
@@ -51,7 +52,7 @@ func TestMake(t *testing.T) {
 
 		expectRMin: -1.0,
 		expectRMax: 1.0,
-		expectIMin: -0.9,
+		expectIMin: -0.1,
 		expectIMax: 0.1,
 	}
 
@@ -67,9 +68,6 @@ func testMake(t *testing.T, helper aspectRatioFixHelper) {
 
 	mock := &MockRenderApplication{
 		MockRenderApplication: base.MockRenderApplication{
-			Base: base.BaseConfig{
-				FixAspect: true,
-			},
 			PictureWidth:  helper.pictureW,
 			PictureHeight: helper.pictureH,
 		},
@@ -83,7 +81,7 @@ func testMake(t *testing.T, helper aspectRatioFixHelper) {
 	actualMax := complex(numerics.RealMax, numerics.ImagMax)
 
 	if !(expectMin == actualMin && expectMax == actualMax) {
-		t.Error("Aspect ratio fix broken.",
+		t.Error("Make changed the plane.",
 			"Expected", expectMin, expectMax,
 			"but received", actualMin, actualMax,
 			"(user input was", userMin, userMax, ")")
@@ -258,3 +256,48 @@ func TestPlaneToPixel(t *testing.T) {
 		}
 	}
 }
+
+func TestSubImage(t *testing.T) {
+	numerics := NativeBaseNumerics{
+		RealMin: -2.0,
+		ImagMin: -1.0,
+		RealMax: 2.0,
+		ImagMax: 1.0,
+	}
+	numerics.WholeWidth = 100
+	numerics.WholeHeight = 50
+	numerics.RestorePicBounds()
+	uq := UnitQuery{100, 50, 4.0, 2.0}
+	numerics.Runit, numerics.Iunit = uq.PixelUnits()
+
+	numerics.SubImage(image.Rect(25, 0, 50, 25))
+
+	min := complex(numerics.RealMin, numerics.ImagMin)
+	max := complex(numerics.RealMax, numerics.ImagMax)
+	if sigDiff(min, -1.0) || sigDiff(max, 1i) {
+		t.Error("Unexpected plane after SubImage:", min, max)
+	}
+}
+
+func TestPixelPosition(t *testing.T) {
+	mock := &MockRenderApplication{
+		MockRenderApplication: base.MockRenderApplication{
+			PictureWidth:  100,
+			PictureHeight: 50,
+		},
+	}
+	mock.PlaneMin = complex(-2.0, -1.0)
+	mock.PlaneMax = complex(2.0, 1.0)
+	numerics := Make(mock)
+
+	expect := numerics.PixelToPlane(30, 10)
+	if actual := numerics.PixelPosition(30, 10); sigDiff(actual, expect) {
+		t.Error("Expected pixel position", expect, "but received:", actual)
+	}
+
+	whole := numerics.PixelPosition(30, 10)
+	numerics.SubImage(image.Rect(25, 0, 50, 25))
+	if actual := numerics.PixelPosition(30, 10); actual != whole {
+		t.Error("Expected pixel position", whole, "after SubImage, but received:", actual)
+	}
+}
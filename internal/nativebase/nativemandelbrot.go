@@ -2,25 +2,216 @@ package nativebase
 
 import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math/cmplx"
 )
 
 type NativeEscapeValue struct {
 	base.EscapeValue
+	// Point on the plane
 	C                complex128
 	SqrtDivergeLimit float64
+	// Julia set constant, used when Julia is true
+	Julia bool
+	Seed  complex128
+	// Power of z.  Zero means 2.
+	Exponent uint
+	// Iteration formula, where nil means the Mandelbrot formula
+	Formula Formula
+	// Skip points inside the main cardioid and period-2 bulb.  Only valid for z^2 + c.
+	CardioidCheck bool
+	// Stop iterating when the orbit is found to cycle
+	PeriodicityCheck bool
+	// Track the derivative of the orbit to estimate distance from the set
+	TrackDerivative bool
+	// Size of a pixel on the plane, to scale the distance estimate
+	PixelSize float64
+	// Find the period and interior distance of members
+	TrackInterior bool
 }
 
-func (member *NativeEscapeValue) Mandelbrot(iterateLimit uint8) {
+func (member *NativeEscapeValue) Mandelbrot(iterateLimit uint32) {
+	if member.CardioidCheck && interior(member.C) {
+		member.InSet = true
+		member.InvDiv = iterateLimit
+		member.Smooth = float64(iterateLimit)
+		if member.TrackInterior {
+			z, period := base.BulbCycle(member.C)
+			member.InteriorEstimate(z, member.C, period, member.PixelSize)
+		}
+		return
+	}
+
 	var z complex128 = 0
 	sqrtDl := member.SqrtDivergeLimit
 	c := member.C
-	i := uint8(0)
-	for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
-		z = (z * z) + c
+	if member.Julia {
+		z = member.C
+		c = member.Seed
+	}
+	n := member.Exponent
+	formula := member.Formula
+	if formula == nil {
+		formula = MandelbrotFormula{}
+	}
+	i := uint32(0)
+	var dz complex128
+	if member.TrackDerivative {
+		z, dz, i = member.derive(z, c, formula, iterateLimit)
+	} else if member.PeriodicityCheck {
+		z, i = member.brent(z, c, formula, iterateLimit)
+	} else if isMandelbrot(formula) {
+		// Avoid the cost of calling through an interface for the most common formula
+		for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
+			z = power(z, n) + c
+		}
+	} else {
+		for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
+			z = formula.Iterate(z, c, n)
+		}
 	}
 
 	member.InSet = i >= iterateLimit
 	member.InvDiv = i
+
+	if member.InSet {
+		member.Smooth = float64(iterateLimit)
+		if member.TrackInterior {
+			member.estimateInterior(z, c, formula, iterateLimit)
+		}
+		return
+	}
+
+	dc := member.derivativeStep()
+	for k := uint32(0); k < base.SmoothIterations; k++ {
+		if member.TrackDerivative {
+			dz = formula.Derive(z, dz, n) + dc
+		}
+		z = formula.Iterate(z, c, n)
+	}
+	member.Smooth = base.MultibrotSmoothEscape(i+base.SmoothIterations, cmplx.Abs(z), n)
+
+	if member.TrackDerivative {
+		member.Estimate(z, dz, member.PixelSize)
+	}
+}
+
+// estimateInterior finds the period of the cycle that z has converged to, and for z^2 + c, the
+// distance to the boundary of its hyperbolic component.
+func (member *NativeEscapeValue) estimateInterior(z, c complex128, formula Formula, iterateLimit uint32) {
+	n := member.Exponent
+	step := func(w complex128) complex128 {
+		return formula.Iterate(w, c, n)
+	}
+	period := base.Period(z, step, iterateLimit)
+
+	if member.Julia || n > 2 || !isMandelbrot(formula) {
+		member.Period = period
+		return
+	}
+	member.InteriorEstimate(z, c, period, member.PixelSize)
+}
+
+// derive iterates z and its derivative until z escapes or its orbit cycles.
+func (member *NativeEscapeValue) derive(z, c complex128, formula Formula, iterateLimit uint32) (complex128, complex128, uint32) {
+	sqrtDl := member.SqrtDivergeLimit
+	n := member.Exponent
+	check := member.PeriodicityCheck
+
+	// The Mandelbrot set is differentiated by c, where z starts at zero, and Julia sets by
+	// the initial value of z.
+	var dz complex128 = 0
+	if member.Julia {
+		dz = 1
+	}
+	dc := member.derivativeStep()
+
+	cyc := makeCycle(z)
+	i := uint32(0)
+	for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
+		dz = formula.Derive(z, dz, n) + dc
+		z = formula.Iterate(z, c, n)
+
+		if check && cyc.repeats(z) {
+			return z, dz, iterateLimit
+		}
+	}
+
+	return z, dz, i
+}
+
+// derivativeStep is the derivative of c with respect to the variable of differentiation
+func (member *NativeEscapeValue) derivativeStep() complex128 {
+	if member.Julia {
+		return 0
+	}
+	return 1
+}
+
+// brent iterates z until it escapes, or until Brent's algorithm finds that its orbit cycles.
+// A cycling orbit never escapes, so is given the full iterate limit.
+func (member *NativeEscapeValue) brent(z, c complex128, formula Formula, iterateLimit uint32) (complex128, uint32) {
+	sqrtDl := member.SqrtDivergeLimit
+	n := member.Exponent
+	mandelbrot := isMandelbrot(formula)
+
+	cyc := makeCycle(z)
+	i := uint32(0)
+	for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
+		if mandelbrot {
+			z = power(z, n) + c
+		} else {
+			z = formula.Iterate(z, c, n)
+		}
+
+		if cyc.repeats(z) {
+			return z, iterateLimit
+		}
+	}
+
+	return z, i
+}
+
+// cycle detects repeating orbits using Brent's algorithm
+type cycle struct {
+	saved  complex128
+	period uint32
+	steps  uint32
+}
+
+func makeCycle(z complex128) cycle {
+	return cycle{saved: z, period: 1}
+}
+
+// repeats is true when z has been seen before.  It must be called with each value in the orbit.
+func (cyc *cycle) repeats(z complex128) bool {
+	if z == cyc.saved {
+		return true
+	}
+
+	cyc.steps++
+	if cyc.steps == cyc.period {
+		cyc.saved = z
+		cyc.period *= 2
+		cyc.steps = 0
+	}
+	return false
+}
+
+// interior is true when c lies inside the main cardioid or the period-2 bulb of the
+// Mandelbrot set.
+func interior(c complex128) bool {
+	x := real(c)
+	y := imag(c)
+	yy := y * y
+
+	xq := x - 0.25
+	q := (xq * xq) + yy
+	if q*(q+xq) <= 0.25*yy {
+		return true
+	}
+
+	xb := x + 1
+	return (xb*xb)+yy <= 0.0625
 }
 
 func withinMandLimit(z complex128, limit float64) bool {
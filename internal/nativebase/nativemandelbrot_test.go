@@ -7,7 +7,7 @@ import (
 func TestMandelbrotSanity(t *testing.T) {
 	const origin complex128 = 0
 	const non complex128 = 2 + 4i
-	const iterateLimit uint8 = 255
+	const iterateLimit uint32 = 255
 	const sqrtDivergeLimit float64 = 2
 
 	originMember := NativeEscapeValue{C: origin, SqrtDivergeLimit: sqrtDivergeLimit}
@@ -29,3 +29,219 @@ func TestMandelbrotSanity(t *testing.T) {
 	}
 
 }
+
+func TestMandelbrotDeepIterations(t *testing.T) {
+	// Points just beyond the cusp of the main cardioid escape slowly
+	const slow complex128 = 0.2501
+	const iterateLimit uint32 = 1000
+	const sqrtDivergeLimit float64 = 2
+
+	slowMember := NativeEscapeValue{C: slow, SqrtDivergeLimit: sqrtDivergeLimit}
+	slowMember.Mandelbrot(iterateLimit)
+
+	if slowMember.InSet {
+		t.Error("Expected ", slowMember, " to be outside Mandelbrot set")
+	}
+
+	if slowMember.InvDiv <= 255 {
+		t.Error("Expected slow escape beyond 255 iterations but was", slowMember.InvDiv)
+	}
+}
+
+func TestMandelbrotSmooth(t *testing.T) {
+	const iterateLimit uint32 = 255
+	const sqrtDivergeLimit float64 = 2
+
+	near := NativeEscapeValue{C: -0.75 + 0.1i, SqrtDivergeLimit: sqrtDivergeLimit}
+	near.Mandelbrot(iterateLimit)
+
+	if near.InSet {
+		t.Fatal("Expected ", near, " to be outside Mandelbrot set")
+	}
+
+	lower := float64(near.InvDiv) - 1
+	upper := float64(near.InvDiv) + 2
+	if near.Smooth < lower || near.Smooth > upper {
+		t.Error("Expected smooth escape value near", near.InvDiv, "but was", near.Smooth)
+	}
+}
+
+func TestJuliaSanity(t *testing.T) {
+	const iterateLimit uint32 = 255
+	const sqrtDivergeLimit float64 = 2
+
+	// With a zero seed, the Julia set is the unit disk
+	inside := NativeEscapeValue{C: 0.5 + 0.5i, SqrtDivergeLimit: sqrtDivergeLimit, Julia: true}
+	outside := NativeEscapeValue{C: 0.8 + 0.8i, SqrtDivergeLimit: sqrtDivergeLimit, Julia: true}
+
+	inside.Mandelbrot(iterateLimit)
+	outside.Mandelbrot(iterateLimit)
+
+	if !inside.InSet {
+		t.Error("Expected ", inside, " to be inside Julia set")
+	}
+
+	if outside.InSet {
+		t.Error("Expected ", outside, " to be outside Julia set")
+	}
+
+	// The origin is in the Mandelbrot set, but not the Julia set for seed 1
+	escape := NativeEscapeValue{SqrtDivergeLimit: sqrtDivergeLimit, Julia: true, Seed: 1}
+	escape.Mandelbrot(iterateLimit)
+
+	if escape.InSet {
+		t.Error("Expected origin to be outside Julia set for seed 1")
+	}
+}
+
+func TestMultibrotSanity(t *testing.T) {
+	const iterateLimit uint32 = 255
+	const sqrtDivergeLimit float64 = 2
+
+	// z^3 + 0.2 has an attracting fixed point
+	inside := NativeEscapeValue{C: 0.2, SqrtDivergeLimit: sqrtDivergeLimit, Exponent: 3}
+	// -0.5 is in the Mandelbrot set but not the cubic Multibrot set
+	outside := NativeEscapeValue{C: -0.5, SqrtDivergeLimit: sqrtDivergeLimit, Exponent: 3}
+
+	inside.Mandelbrot(iterateLimit)
+	outside.Mandelbrot(iterateLimit)
+
+	if !inside.InSet {
+		t.Error("Expected ", inside, " to be inside Multibrot set")
+	}
+
+	if outside.InSet {
+		t.Error("Expected ", outside, " to be outside Multibrot set")
+	}
+}
+
+func TestInterior(t *testing.T) {
+	inside := []complex128{0, -0.5, 0.25, 0.2 + 0.5i, -1, -1.2}
+	outside := []complex128{0.3, -0.75 + 0.1i, -1.3, 0.5i + 0.5, -2}
+
+	for _, c := range inside {
+		if !interior(c) {
+			t.Error("Expected", c, "to be inside cardioid or period-2 bulb")
+		}
+	}
+
+	for _, c := range outside {
+		if interior(c) {
+			t.Error("Expected", c, "to be outside cardioid and period-2 bulb")
+		}
+	}
+}
+
+func TestInteriorChecksMatch(t *testing.T) {
+	const iterateLimit uint32 = 500
+	const sqrtDivergeLimit float64 = 2
+
+	for x := -2.0; x < 0.6; x += 0.05 {
+		for y := -1.2; y < 1.2; y += 0.05 {
+			c := complex(x, y)
+			plain := NativeEscapeValue{C: c, SqrtDivergeLimit: sqrtDivergeLimit}
+			checked := NativeEscapeValue{
+				C:                c,
+				SqrtDivergeLimit: sqrtDivergeLimit,
+				CardioidCheck:    true,
+				PeriodicityCheck: true,
+			}
+
+			plain.Mandelbrot(iterateLimit)
+			checked.Mandelbrot(iterateLimit)
+
+			if plain.EscapeValue != checked.EscapeValue {
+				t.Error("Interior checks changed escape at", c, "from", plain.EscapeValue,
+					"to", checked.EscapeValue)
+			}
+		}
+	}
+}
+
+func TestDistanceEstimate(t *testing.T) {
+	const iterateLimit uint32 = 500
+
+	// The set meets the positive real axis at 0.25
+	for _, x := range []float64{0.5, 1.0, 1.5} {
+		member := NativeEscapeValue{
+			C:                complex(x, 0),
+			SqrtDivergeLimit: 2,
+			TrackDerivative:  true,
+			PixelSize:        1,
+		}
+		member.Mandelbrot(iterateLimit)
+
+		// The estimate is within a factor of four of the true distance
+		actual := member.Distance
+		expect := x - 0.25
+		if actual < expect/4 || actual > expect*4 {
+			t.Error("Expected distance near", expect, "at", x, "but was", actual)
+		}
+
+		if real(member.Normal) <= 0 {
+			t.Error("Expected normal to point away from the set at", x, "but was", member.Normal)
+		}
+	}
+}
+
+func TestTrackDerivativeMatches(t *testing.T) {
+	const iterateLimit uint32 = 300
+	const sqrtDivergeLimit float64 = 2
+
+	for x := -2.0; x < 0.6; x += 0.1 {
+		for y := -1.2; y < 1.2; y += 0.1 {
+			c := complex(x, y)
+			plain := NativeEscapeValue{C: c, SqrtDivergeLimit: sqrtDivergeLimit}
+			tracked := plain
+			tracked.TrackDerivative = true
+			tracked.PeriodicityCheck = true
+
+			plain.Mandelbrot(iterateLimit)
+			tracked.Mandelbrot(iterateLimit)
+
+			if plain.InvDiv != tracked.InvDiv || plain.Smooth != tracked.Smooth {
+				t.Error("Tracking derivative changed escape at", c, "from", plain.EscapeValue,
+					"to", tracked.EscapeValue)
+			}
+		}
+	}
+}
+
+func TestInteriorPeriod(t *testing.T) {
+	const iterateLimit uint32 = 1000
+
+	// Centres of hyperbolic components, and the "airplane" and "rabbit" components of period 3
+	points := map[complex128]uint32{
+		-0.1:                      1,
+		-1.05:                     2,
+		-1.7549:                   3,
+		-0.1226 + 0.7449i:         3,
+		-0.15652 + 1.03225i:       4,
+		-1.3107 + 0.0000000001i:   4,
+		0.5 + 0.5i:                0,
+		-0.7436 + 0.1318i:         0,
+		complex(-0.75, 0.0000001): 0,
+	}
+
+	for c, expect := range points {
+		for _, cardioid := range []bool{false, true} {
+			member := NativeEscapeValue{
+				C:                c,
+				SqrtDivergeLimit: 2,
+				CardioidCheck:    cardioid,
+				PeriodicityCheck: true,
+				TrackInterior:    true,
+				PixelSize:        0.01,
+			}
+			member.Mandelbrot(iterateLimit)
+
+			if member.Period != expect {
+				t.Error("Expected period", expect, "at", c, "but received", member.Period)
+			}
+
+			if expect > 0 && member.Distance <= 0 {
+				t.Error("Expected positive interior distance at", c, "but was", member.Distance)
+			}
+		}
+	}
+}
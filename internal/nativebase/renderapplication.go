@@ -6,6 +6,8 @@ import (
 
 type NativeCoordProvider interface {
 	NativeUserCoords() (complex128, complex128)
+	NativeJuliaSeed() complex128
+	NativeFormula() Formula
 }
 
 type RenderApplication interface {
@@ -32,7 +32,7 @@ type NativeSequenceProxy struct {
 func (proxy NativeSequenceProxy) ClaimExtrinsics() {
 	base := proxy.NativeSequenceNumerics.NativeBaseNumerics
 	rectangle := proxy.LocalRegion.rect(&base)
-	proxy.NativeSequenceNumerics.SubImage(rectangle)
+	proxy.NativeSequenceNumerics.SubImage(rectangle.Intersect(proxy.TileRect()))
 }
 
 func (proxy NativeSequenceProxy) Extrinsically(f func()) {
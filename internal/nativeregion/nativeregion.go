@@ -70,7 +70,6 @@ func (native *NativeRegionNumerics) Extrinsically(f func()) {
 }
 
 // Return the children of this region
-// This implementation does not create many new objects
 func (native *NativeRegionNumerics) Children() []region.RegionNumerics {
 	const childCount = 4
 	if native.subregion.populated {
@@ -85,7 +84,6 @@ func (native *NativeRegionNumerics) Children() []region.RegionNumerics {
 }
 
 // Return the children of this region without hiding their types
-// This implementation does not create many new objects
 func (native *NativeRegionNumerics) NativeChildRegions() []nativeRegion {
 	if native.subregion.populated {
 		return native.subregion.children
@@ -99,17 +97,27 @@ func (native *NativeRegionNumerics) RegionSequence() region.ProxySequence {
 }
 
 func (native *NativeRegionNumerics) NativeSequence() NativeSequenceProxy {
-	seq := NativeSequenceProxy{
+	numerics := native.SequenceNumerics
+	if native.Concurrent {
+		copied := *numerics
+		numerics = &copied
+	}
+	return NativeSequenceProxy{
 		LocalRegion:            native.Region,
-		NativeSequenceNumerics: native.SequenceNumerics,
+		NativeSequenceNumerics: numerics,
 	}
-	return seq
 }
 
 func (native *NativeRegionNumerics) Proxy(region nativeRegion) NativeRegionProxy {
+	numerics := native
+	if native.Concurrent {
+		copied := *native
+		copied.subregion = nativeSubregion{}
+		numerics = &copied
+	}
 	return NativeRegionProxy{
 		LocalRegion:          region,
-		NativeRegionNumerics: native,
+		NativeRegionNumerics: numerics,
 	}
 }
 
@@ -179,17 +187,17 @@ func (native *NativeRegionNumerics) Points() []nativebase.NativeEscapeValue {
 	}
 }
 
-func (native *NativeRegionNumerics) SampleDivs() (<-chan uint8, chan<- bool) {
+func (native *NativeRegionNumerics) SampleDivs() (<-chan uint32, chan<- bool) {
 	done := make(chan bool, 1)
-	idivch := make(chan uint8)
+	idivch := make(chan uint32)
 
 	go native.sample(idivch, done)
 
 	return idivch, done
 }
 
-func (native *NativeRegionNumerics) sample(idivch chan<- uint8, done <-chan bool) {
-	complete := func(idiv uint8) bool {
+func (native *NativeRegionNumerics) sample(idivch chan<- uint32, done <-chan bool) {
+	complete := func(idiv uint32) bool {
 		select {
 		case <-done:
 			close(idivch)
@@ -200,7 +208,7 @@ func (native *NativeRegionNumerics) sample(idivch chan<- uint8, done <-chan bool
 		}
 	}
 
-	eval := func(r, i float64) uint8 {
+	eval := func(r, i float64) uint32 {
 		p := native.Escape(complex(r, i))
 		return p.InvDiv
 	}
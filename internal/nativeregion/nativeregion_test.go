@@ -145,7 +145,7 @@ func TestRect(t *testing.T) {
 }
 
 func testRegionSplit(helper NativeRegionSplitHelper, t *testing.T) {
-	const iterlim = uint8(255)
+	const iterlim = uint32(255)
 	parent := nativebase.NativeBaseNumerics{}
 	parent.SqrtDivergeLimit = sqrtDLimit
 	parent.IterateLimit = iterlim
@@ -22,28 +22,27 @@ func Make(app nativebase.RenderApplication) NativeSequenceNumerics {
 func (nsn *NativeSequenceNumerics) Sequence() []base.PixelMember {
 	ileft, itop := nsn.PictureMin()
 	iright, ibott := nsn.PictureMax()
-	rUnit, iUnit := nsn.PixelSize()
-	sqrtDl := nsn.SqrtDivergeLimit
 	iterlim := nsn.IterateLimit
 
 	area := (iright - ileft) * (ibott - itop)
 	out := make([]base.PixelMember, area)
 
 	count := 0
-	x := nsn.RealMin
 	for i := ileft; i < iright; i++ {
-		y := nsn.ImagMax
+		if nsn.Cancelled() {
+			break
+		}
+		column := count
 		for j := itop; j < ibott; j++ {
-			member := nativebase.NativeEscapeValue{
-				C:                complex(x, y),
-				SqrtDivergeLimit: sqrtDl,
+			if !nsn.Lattice.Contains(i, j) {
+				continue
 			}
+			member := nsn.CreateMandelbrot(nsn.PixelPosition(i, j))
 			member.Mandelbrot(iterlim)
 			out[count] = base.PixelMember{I: i, J: j, Member: member.EscapeValue}
-			y -= iUnit
 			count++
 		}
-		x += rUnit
+		nsn.Tracker.AddPixels(count - column)
 	}
-	return out
+	return out[:count]
 }
@@ -30,3 +30,22 @@ func TestSequence(t *testing.T) {
 		t.Error("Expected", expectedCount, "members but there were", actualCount)
 	}
 }
+
+func TestSequenceCancelled(t *testing.T) {
+	done := make(chan struct{})
+	close(done)
+	app := &nativebase.MockRenderApplication{
+		MockRenderApplication: base.MockRenderApplication{
+			PictureWidth:  10,
+			PictureHeight: 10,
+			Base:          base.BaseConfig{DivergeLimit: 4.0, IterateLimit: 10, Done: done},
+		},
+	}
+	app.PlaneMin = complex(0.0, 0.0)
+	app.PlaneMax = complex(10.0, 10.0)
+	numerics := Make(app)
+
+	if out := numerics.Sequence(); len(out) != 0 {
+		t.Error("Expected no members after cancellation, but there were", len(out))
+	}
+}
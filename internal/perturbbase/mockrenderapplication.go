@@ -0,0 +1,23 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+)
+
+type MockRenderApplication struct {
+	bigbase.MockRenderApplication
+	MockSeriesProvider
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
+
+type MockSeriesProvider struct {
+	TSeriesConfig bool
+
+	Series SeriesConfig
+}
+
+func (msp *MockSeriesProvider) SeriesConfig() SeriesConfig {
+	msp.TSeriesConfig = true
+	return msp.Series
+}
@@ -0,0 +1,117 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"math"
+	"math/cmplx"
+)
+
+// PerturbBaseNumerics is the basis for perturbation numerics.  The embedded native numerics work
+// on a plane translated so that the reference point lies at the origin.
+type PerturbBaseNumerics struct {
+	nativebase.NativeBaseNumerics
+	Orbit  *ReferenceOrbit
+	Series *Series
+}
+
+// Reference holds the orbit of the reference point and the series approximation about it.
+// Both depend only on the frame, so numerics for different parts of a picture may share them.
+type Reference struct {
+	Orbit  *ReferenceOrbit
+	Series *Series
+}
+
+func Make(app RenderApplication) PerturbBaseNumerics {
+	return MakeShared(app, &Reference{})
+}
+
+// MakeShared is like Make, but reuses the orbit and series held by ref.  If ref is empty, they
+// are computed and stored in ref for the next numerics of the same frame.
+func MakeShared(app RenderApplication, ref *Reference) PerturbBaseNumerics {
+	big := bigbase.Make(app)
+
+	// Reference point is at the centre of the image
+	bigTwo := big.MakeBigFloat(2.0)
+	centre := big.MakeBigComplex(0.0, 0.0)
+	centre.R.Add(&big.RealMin, &big.RealMax)
+	centre.R.Quo(&centre.R, &bigTwo)
+	centre.I.Add(&big.ImagMin, &big.ImagMax)
+	centre.I.Quo(&centre.I, &bigTwo)
+
+	delta := func(x, origin *bigbase.BigComplex) complex128 {
+		d := big.MakeBigComplex(0.0, 0.0)
+		d.R.Sub(x.Real(), origin.Real())
+		d.I.Sub(x.Imag(), origin.Imag())
+		return native(&d)
+	}
+
+	planeMin := bigbase.BigComplex{R: big.RealMin, I: big.ImagMin}
+	planeMax := bigbase.BigComplex{R: big.RealMax, I: big.ImagMax}
+
+	translated := deltaApp{
+		RenderApplication: app,
+		min:               delta(&planeMin, &centre),
+		max:               delta(&planeMax, &centre),
+	}
+
+	parent := nativebase.Make(translated)
+	if ref.Orbit == nil {
+		*ref = makeReference(app, &big, &centre, translated, parent)
+	}
+
+	return PerturbBaseNumerics{
+		NativeBaseNumerics: parent,
+		Orbit:              ref.Orbit,
+		Series:             ref.Series,
+	}
+}
+
+func makeReference(app RenderApplication, big *bigbase.BigBaseNumerics, centre *bigbase.BigComplex,
+	translated deltaApp, parent nativebase.NativeBaseNumerics) Reference {
+	prec := big.Precision
+	var orbit *ReferenceOrbit
+	if big.JuliaSeed != nil {
+		orbit = MakeJuliaOrbit(centre, big.JuliaSeed, prec, parent.IterateLimit, parent.SqrtDivergeLimit)
+	} else {
+		orbit = MakeReferenceOrbit(centre, prec, parent.IterateLimit, parent.SqrtDivergeLimit)
+	}
+
+	// The corners of the plane are the points furthest from the reference, and the midpoints of
+	// the edges are the nearest points on the boundary
+	low, high := translated.min, translated.max
+	mid := (low + high) / 2
+	probes := []complex128{
+		low,
+		high,
+		complex(real(low), imag(high)),
+		complex(real(high), imag(low)),
+		complex(real(low), imag(mid)),
+		complex(real(high), imag(mid)),
+		complex(real(mid), imag(low)),
+		complex(real(mid), imag(high)),
+	}
+	radius := 0.0
+	for _, p := range probes {
+		radius = math.Max(radius, cmplx.Abs(p))
+	}
+	series := MakeSeries(orbit, app.SeriesConfig(), radius, probes, parent.IterateLimit)
+
+	return Reference{Orbit: orbit, Series: series}
+}
+
+// CreateMandelbrot creates a member at distance dc from the reference point
+func (pbn *PerturbBaseNumerics) CreateMandelbrot(dc complex128) PerturbEscapeValue {
+	return PerturbEscapeValue{
+		C:                dc,
+		SqrtDivergeLimit: pbn.SqrtDivergeLimit,
+		Orbit:            pbn.Orbit,
+		Series:           pbn.Series,
+	}
+}
+
+func (pbn *PerturbBaseNumerics) Escape(dc complex128) PerturbEscapeValue {
+	point := pbn.CreateMandelbrot(dc)
+	point.Mandelbrot(pbn.IterateLimit)
+	return point
+}
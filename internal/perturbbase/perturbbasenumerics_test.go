@@ -0,0 +1,42 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	app := &MockRenderApplication{}
+	app.Base = base.BaseConfig{DivergeLimit: 4.0, IterateLimit: 100}
+	app.PictureWidth = 10
+	app.PictureHeight = 10
+	app.Prec = testPrec
+	app.UserMin = bigbase.MakeBigComplex(-1.0, -1.0, testPrec)
+	app.UserMax = bigbase.MakeBigComplex(0.0, 1.0, testPrec)
+
+	numerics := Make(app)
+
+	okay := numerics.RealMin == -0.5 && numerics.RealMax == 0.5
+	okay = okay && numerics.ImagMin == -1.0 && numerics.ImagMax == 1.0
+	if !okay {
+		t.Error("Expected plane translated to reference point, but was", numerics.NativeBaseNumerics)
+	}
+
+	if numerics.Series.Skip != 0 {
+		t.Error("Expected no iterations skipped when series disabled, but skipped", numerics.Series.Skip)
+	}
+
+	if numerics.Orbit.C != -0.5 {
+		t.Error("Expected reference point at centre of plane but was", numerics.Orbit.C)
+	}
+
+	if _, ok := numerics.Formula.(nativebase.MandelbrotFormula); !ok {
+		t.Error("Expected Mandelbrot formula but received", numerics.Formula)
+	}
+
+	if !(app.TBigUserCoords && app.TPrecision && app.TSeriesConfig) {
+		t.Error("Expected methods not called on mock", app)
+	}
+}
@@ -0,0 +1,88 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"math/cmplx"
+)
+
+// PerturbEscapeValue is a point described by its distance from a reference orbit
+type PerturbEscapeValue struct {
+	base.EscapeValue
+	// Distance from the reference point
+	C                complex128
+	SqrtDivergeLimit float64
+	Orbit            *ReferenceOrbit
+	// Optional series approximation of the early iterations
+	Series *Series
+}
+
+func (member *PerturbEscapeValue) Mandelbrot(iterateLimit uint32) {
+	orbit := member.Orbit.Z
+	last := len(orbit) - 1
+	dc := member.C
+	sqrtDl := member.SqrtDivergeLimit
+
+	julia := member.Orbit.Julia
+
+	// Julia set points start at their distance from the reference; Mandelbrot points at zero
+	var z complex128 = 0
+	var delta complex128 = 0
+	var step complex128 = dc
+	if julia {
+		z = orbit[0] + dc
+		delta = dc
+		step = 0
+	}
+	m := 0
+	i := uint32(0)
+	if member.Series != nil && member.Series.Skip > 0 {
+		delta = member.Series.Delta(dc)
+		m = int(member.Series.Skip)
+		i = member.Series.Skip
+		z = orbit[m] + delta
+	}
+	for ; i < iterateLimit && withinMandLimit(z, sqrtDl); i++ {
+		delta = (2 * orbit[m] * delta) + (delta * delta) + step
+		m++
+		z = orbit[m] + delta
+
+		// Glitch: the point passed closer to zero than to the reference orbit, or the reference
+		// escaped first.  Rebase on to the start of the reference orbit.  Only the Mandelbrot
+		// orbit is guaranteed to pass through zero.
+		if m == last || (!julia && sqabs(z) < sqabs(delta)) {
+			delta = z - orbit[0]
+			m = 0
+		}
+	}
+
+	member.InSet = i >= iterateLimit
+	member.InvDiv = i
+
+	if member.InSet {
+		member.Smooth = float64(iterateLimit)
+		return
+	}
+
+	c := member.Orbit.C
+	if !julia {
+		c += dc
+	}
+	for k := uint32(0); k < base.SmoothIterations; k++ {
+		z = (z * z) + c
+	}
+	member.Smooth = base.SmoothEscape(i+base.SmoothIterations, cmplx.Abs(z))
+}
+
+func sqabs(z complex128) float64 {
+	x := real(z)
+	y := imag(z)
+	return (x * x) + (y * y)
+}
+
+func withinMandLimit(z complex128, limit float64) bool {
+	// Approximate cmplx.Abs
+	negLimit := -limit
+	x := real(z)
+	y := imag(z)
+	return x < limit && x > negLimit && y < limit && y > negLimit
+}
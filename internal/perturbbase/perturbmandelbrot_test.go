@@ -0,0 +1,159 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+	"testing"
+)
+
+const testPrec uint = 53
+
+func TestPerturbMandelbrotSanity(t *testing.T) {
+	const iterateLimit uint32 = 255
+	const sqrtDivergeLimit float64 = 2
+
+	ref := bigbase.MakeBigComplex(-0.5, 0.0, testPrec)
+	orbit := MakeReferenceOrbit(&ref, testPrec, iterateLimit, sqrtDivergeLimit)
+
+	originMember := PerturbEscapeValue{C: 0.5, SqrtDivergeLimit: sqrtDivergeLimit, Orbit: orbit}
+	nonMember := PerturbEscapeValue{C: 2.5 + 4i, SqrtDivergeLimit: sqrtDivergeLimit, Orbit: orbit}
+
+	originMember.Mandelbrot(iterateLimit)
+	nonMember.Mandelbrot(iterateLimit)
+
+	if !originMember.InSet {
+		t.Error("Expected origin to be in Mandelbrot set")
+	}
+
+	if nonMember.InSet {
+		t.Error("Expected ", nonMember, " to be outside Mandelbrot set")
+	}
+}
+
+func TestPerturbMatchesNative(t *testing.T) {
+	const iterateLimit uint32 = 500
+	const sqrtDivergeLimit float64 = 2
+	const refpoint complex128 = -0.75 + 0.1i
+
+	ref := bigbase.MakeBigComplex(real(refpoint), imag(refpoint), testPrec)
+	orbit := MakeReferenceOrbit(&ref, testPrec, iterateLimit, sqrtDivergeLimit)
+
+	points := []complex128{
+		-0.7501 + 0.1001i,
+		-0.74 + 0.11i,
+		-0.76 + 0.09i,
+		0.3 + 0.5i,
+		-1.5 + 0.0i,
+	}
+
+	for _, c := range points {
+		perturb := PerturbEscapeValue{
+			C:                c - refpoint,
+			SqrtDivergeLimit: sqrtDivergeLimit,
+			Orbit:            orbit,
+		}
+		native := nativebase.NativeEscapeValue{C: c, SqrtDivergeLimit: sqrtDivergeLimit}
+
+		perturb.Mandelbrot(iterateLimit)
+		native.Mandelbrot(iterateLimit)
+
+		if perturb.InSet != native.InSet || perturb.InvDiv != native.InvDiv {
+			t.Error("Perturbation escape", perturb.EscapeValue,
+				"differed from native escape", native.EscapeValue,
+				"at", c)
+		}
+	}
+}
+
+func TestPerturbJuliaMatchesNative(t *testing.T) {
+	const iterateLimit uint32 = 300
+	const sqrtDivergeLimit float64 = 2
+	const refpoint complex128 = 0.1 + 0.1i
+	const seed complex128 = -0.8 + 0.156i
+
+	ref := bigbase.MakeBigComplex(real(refpoint), imag(refpoint), testPrec)
+	bigSeed := bigbase.MakeBigComplex(real(seed), imag(seed), testPrec)
+	orbit := MakeJuliaOrbit(&ref, &bigSeed, testPrec, iterateLimit, sqrtDivergeLimit)
+
+	points := []complex128{
+		0.1001 + 0.1001i,
+		0.12 + 0.09i,
+		-0.3 + 0.2i,
+		1.5,
+	}
+
+	for _, c := range points {
+		perturb := PerturbEscapeValue{
+			C:                c - refpoint,
+			SqrtDivergeLimit: sqrtDivergeLimit,
+			Orbit:            orbit,
+		}
+		native := nativebase.NativeEscapeValue{
+			C:                c,
+			SqrtDivergeLimit: sqrtDivergeLimit,
+			Julia:            true,
+			Seed:             seed,
+		}
+
+		perturb.Mandelbrot(iterateLimit)
+		native.Mandelbrot(iterateLimit)
+
+		if perturb.InSet != native.InSet || perturb.InvDiv != native.InvDiv {
+			t.Error("Perturbation Julia escape", perturb.EscapeValue,
+				"differed from native escape", native.EscapeValue,
+				"at", c)
+		}
+	}
+}
+
+func TestPerturbDeepMatchesBig(t *testing.T) {
+	const iterateLimit uint32 = 1000
+	const sqrtDivergeLimit float64 = 2
+	const deepPrec uint = 100
+	const spacing = 1e-20
+
+	// Beside the Misiurewicz point i, where the reference escapes before some of its neighbours
+	ref := bigbase.MakeBigComplex(0.0, 0.0, deepPrec)
+	ref.R.SetString("1e-20")
+	ref.I.SetString("1.00000000000000000001")
+	orbit := MakeReferenceOrbit(&ref, deepPrec, iterateLimit, sqrtDivergeLimit)
+
+	sqrtDL := bigbase.MakeBigFloat(sqrtDivergeLimit, deepPrec)
+	rebased := false
+	// Offset by half the spacing, to avoid the point i itself, whose orbit is only bounded in
+	// exact arithmetic
+	for x := -2.5; x < 2.5; x++ {
+		for y := -2.5; y < 2.5; y++ {
+			dc := complex(x*spacing, y*spacing)
+			perturb := PerturbEscapeValue{
+				C:                dc,
+				SqrtDivergeLimit: sqrtDivergeLimit,
+				Orbit:            orbit,
+			}
+
+			c := bigbase.MakeBigComplex(real(dc), imag(dc), deepPrec)
+			c.Add(&c, &ref)
+			exact := bigbase.BigEscapeValue{
+				C:                &c,
+				SqrtDivergeLimit: &sqrtDL,
+				Prec:             deepPrec,
+			}
+
+			perturb.Mandelbrot(iterateLimit)
+			exact.Mandelbrot(iterateLimit)
+
+			if perturb.InSet != exact.InSet || perturb.InvDiv != exact.InvDiv {
+				t.Error("Perturbation escape", perturb.EscapeValue,
+					"differed from big escape", exact.EscapeValue,
+					"at", dc, "from the reference")
+			}
+
+			// Points that outlast the reference orbit must have been rebased
+			rebased = rebased || int(exact.InvDiv) >= len(orbit.Z)
+		}
+	}
+
+	if !rebased {
+		t.Error("Expected some point to outlast the reference orbit of length", len(orbit.Z))
+	}
+}
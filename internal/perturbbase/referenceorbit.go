@@ -0,0 +1,74 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"math/big"
+)
+
+// ReferenceOrbit is a high precision Mandelbrot orbit, rounded to native numbers after each
+// iteration.
+type ReferenceOrbit struct {
+	// Z[0] is zero for the Mandelbrot set, or the reference point for a Julia set.  The orbit
+	// ends after the first escaped value, or at the iterate limit.
+	Z []complex128
+	// Native approximation of the reference point, or of the seed for a Julia set
+	C complex128
+	// Julia is true if the orbit iterates a Julia set
+	Julia bool
+}
+
+func MakeReferenceOrbit(c *bigbase.BigComplex, prec uint, iterateLimit uint32, sqrtDivergeLimit float64) *ReferenceOrbit {
+	zero := bigbase.MakeBigComplex(0.0, 0.0, prec)
+	return makeOrbit(&zero, c, prec, iterateLimit, sqrtDivergeLimit)
+}
+
+// MakeJuliaOrbit iterates the reference point under the Julia set for seed
+func MakeJuliaOrbit(ref, seed *bigbase.BigComplex, prec uint, iterateLimit uint32, sqrtDivergeLimit float64) *ReferenceOrbit {
+	orbit := makeOrbit(ref, seed, prec, iterateLimit, sqrtDivergeLimit)
+	orbit.Julia = true
+	return orbit
+}
+
+func makeOrbit(start, c *bigbase.BigComplex, prec uint, iterateLimit uint32, sqrtDivergeLimit float64) *ReferenceOrbit {
+	z := bigbase.MakeBigComplex(0.0, 0.0, prec)
+	z.R.Set(start.Real())
+	z.I.Set(start.Imag())
+	aa := bigbase.MakeBigFloat(0.0, prec)
+	bb := bigbase.MakeBigFloat(0.0, prec)
+	ab := bigbase.MakeBigFloat(0.0, prec)
+
+	orbit := make([]complex128, 1, iterateLimit+1)
+	orbit[0] = native(&z)
+
+	for i := uint32(0); i < iterateLimit; i++ {
+		aa.Mul(z.Real(), z.Real())
+		bb.Mul(z.Imag(), z.Imag())
+		ab.Mul(z.Real(), z.Imag())
+
+		z.R.Copy(aa.Sub(&aa, &bb))
+		z.I.Copy(ab.Add(&ab, &ab))
+
+		z.Add(&z, c)
+
+		next := native(&z)
+		orbit = append(orbit, next)
+
+		if !withinMandLimit(next, sqrtDivergeLimit) {
+			break
+		}
+	}
+
+	return &ReferenceOrbit{
+		Z: orbit,
+		C: native(c),
+	}
+}
+
+func native(c *bigbase.BigComplex) complex128 {
+	return complex(float(c.Real()), float(c.Imag()))
+}
+
+func float(f *big.Float) float64 {
+	x, _ := f.Float64()
+	return x
+}
@@ -0,0 +1,35 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/nativebase"
+)
+
+// The reference orbit is computed from the user's big coordinates
+type RenderApplication interface {
+	bigbase.RenderApplication
+	SeriesProvider
+}
+
+// deltaApp presents the plane, translated so the reference point is the origin, as native
+// coordinates.
+type deltaApp struct {
+	base.RenderApplication
+	min complex128
+	max complex128
+}
+
+func (app deltaApp) NativeUserCoords() (complex128, complex128) {
+	return app.min, app.max
+}
+
+// Perturbation numerics store the Julia constant in the reference orbit
+func (app deltaApp) NativeJuliaSeed() complex128 {
+	return 0
+}
+
+// Perturbation numerics iterate only the Mandelbrot formula, and configuration rejects others
+func (app deltaApp) NativeFormula() nativebase.Formula {
+	return nativebase.MandelbrotFormula{}
+}
@@ -0,0 +1,133 @@
+package perturbbase
+
+import (
+	"math/cmplx"
+)
+
+type SeriesConfig struct {
+	// Number of terms in the approximating series.  Zero disables series approximation.
+	Terms uint
+	// Maximum relative error tolerated in the approximation
+	Tolerance float64
+}
+
+type SeriesProvider interface {
+	SeriesConfig() SeriesConfig
+}
+
+// Series approximates the distance of each point from the reference orbit after Skip
+// iterations, as a polynomial in the point's distance from the reference point.  The series is
+// univariate in that distance, since each iteration is analytic.
+type Series struct {
+	Skip   uint32
+	Coeffs []complex128
+}
+
+// MakeSeries finds the iteration count up to which the series approximates every point within
+// radius of the reference point.  The probes are points whose perturbed orbits are used to
+// check the approximation.  Points between the probes are not checked, so features smaller than
+// their spacing may escape the check.
+func MakeSeries(orbit *ReferenceOrbit, config SeriesConfig, radius float64, probes []complex128, iterateLimit uint32) *Series {
+	terms := int(config.Terms)
+	series := &Series{}
+	if terms == 0 {
+		return series
+	}
+
+	coeffs := make([]complex128, terms)
+	next := make([]complex128, terms)
+	deltas := make([]complex128, len(probes))
+
+	// Julia set deltas start at dc and are not offset by it each iteration
+	julia := orbit.Julia
+	var one complex128 = 1
+	if julia {
+		coeffs[0] = 1
+		copy(deltas, probes)
+		one = 0
+	}
+
+	// Orbit must not be exhausted by the skip, since that would force a rebase
+	last := len(orbit.Z) - 2
+	if last < 0 {
+		return series
+	}
+	if uint32(last) >= iterateLimit {
+		last = int(iterateLimit) - 1
+	}
+
+	for n := 0; n < last; n++ {
+		ref := orbit.Z[n]
+		for k := range coeffs {
+			sum := 2 * ref * coeffs[k]
+			if k == 0 {
+				sum += one
+			}
+			for j := 0; j < k; j++ {
+				sum += coeffs[j] * coeffs[k-j-1]
+			}
+			next[k] = sum
+		}
+		coeffs, next = next, coeffs
+
+		if !series.truncationOkay(coeffs, radius, config.Tolerance) {
+			break
+		}
+
+		okay := true
+		for i, dc := range probes {
+			delta := deltas[i]
+			delta = (2 * ref * delta) + (delta * delta) + (one * dc)
+			deltas[i] = delta
+
+			z := orbit.Z[n+1] + delta
+			if sqabs(z) < sqabs(delta) {
+				okay = false
+				break
+			}
+
+			approx := evalSeries(coeffs, dc)
+			if cmplx.Abs(approx-delta) > config.Tolerance*cmplx.Abs(delta) {
+				okay = false
+				break
+			}
+		}
+
+		if !okay {
+			break
+		}
+
+		series.Skip = uint32(n + 1)
+		series.Coeffs = append(series.Coeffs[:0], coeffs...)
+	}
+
+	return series
+}
+
+// The last term of the series must be small compared to the first
+func (series *Series) truncationOkay(coeffs []complex128, radius, tolerance float64) bool {
+	terms := len(coeffs)
+	if terms < 2 {
+		return true
+	}
+	first := cmplx.Abs(coeffs[0]) * radius
+	final := cmplx.Abs(coeffs[terms-1])
+	for i := 0; i < terms; i++ {
+		final *= radius
+	}
+	return final <= tolerance*first
+}
+
+// Approximate distance from the reference orbit after Skip iterations
+func (series *Series) Delta(dc complex128) complex128 {
+	return evalSeries(series.Coeffs, dc)
+}
+
+func evalSeries(coeffs []complex128, dc complex128) complex128 {
+	// Horner's method
+	var sum complex128 = 0
+	for k := len(coeffs) - 1; k >= 0; k-- {
+		sum = (sum + coeffs[k]) * dc
+	}
+	return sum
+}
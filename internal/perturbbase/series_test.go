@@ -0,0 +1,77 @@
+package perturbbase
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"testing"
+)
+
+func TestMakeSeries(t *testing.T) {
+	const iterateLimit uint32 = 1000
+	const sqrtDivergeLimit float64 = 2
+	const radius float64 = 1e-6
+
+	ref := bigbase.MakeBigComplex(-0.7436438870371587, 0.1318259042053119, testPrec)
+	orbit := MakeReferenceOrbit(&ref, testPrec, iterateLimit, sqrtDivergeLimit)
+
+	probes := []complex128{
+		complex(-radius, -radius),
+		complex(radius, radius),
+		complex(-radius, radius),
+		complex(radius, -radius),
+	}
+	config := SeriesConfig{Terms: 8, Tolerance: 1e-6}
+	series := MakeSeries(orbit, config, radius*1.5, probes, iterateLimit)
+
+	if series.Skip == 0 {
+		t.Fatal("Expected series to skip iterations")
+	}
+
+	if len(series.Coeffs) != int(config.Terms) {
+		t.Error("Expected", config.Terms, "coefficients but received", len(series.Coeffs))
+	}
+
+	mismatch := 0
+	const steps = 10
+	for i := 0; i < steps; i++ {
+		for j := 0; j < steps; j++ {
+			dc := complex(radius*(float64(2*i)/steps-1), radius*(float64(2*j)/steps-1))
+			plain := PerturbEscapeValue{C: dc, SqrtDivergeLimit: sqrtDivergeLimit, Orbit: orbit}
+			approx := plain
+			approx.Series = series
+
+			plain.Mandelbrot(iterateLimit)
+			approx.Mandelbrot(iterateLimit)
+
+			if plain.InvDiv != approx.InvDiv {
+				mismatch++
+			}
+		}
+	}
+
+	if mismatch > 2 {
+		t.Error("Series approximation changed", mismatch, "points, skipping", series.Skip)
+	}
+}
+
+func TestMakeSeriesDisabled(t *testing.T) {
+	const iterateLimit uint32 = 100
+
+	ref := bigbase.MakeBigComplex(-0.5, 0.0, testPrec)
+	orbit := MakeReferenceOrbit(&ref, testPrec, iterateLimit, 2)
+
+	series := MakeSeries(orbit, SeriesConfig{}, 0.1, []complex128{0.1}, iterateLimit)
+
+	if series.Skip != 0 {
+		t.Error("Expected no iterations skipped but skipped", series.Skip)
+	}
+}
+
+func TestSeriesDelta(t *testing.T) {
+	series := Series{Coeffs: []complex128{2, 3}}
+	const dc complex128 = 0.5i
+	expect := (2 * dc) + (3 * dc * dc)
+	actual := series.Delta(dc)
+	if expect != actual {
+		t.Error("Expected", expect, "but received", actual)
+	}
+}
@@ -0,0 +1,17 @@
+package perturbregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type MockRenderApplication struct {
+	bigbase.MockBigCoordProvider
+	region.MockRegionProvider
+	base.MockRenderApplication
+	perturbbase.MockSeriesProvider
+}
+
+var _ RenderApplication = (*MockRenderApplication)(nil)
@@ -0,0 +1,48 @@
+package perturbregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/perturbsequence"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type PerturbRegionProxy struct {
+	*PerturbRegionNumerics
+	LocalRegion perturbRegion
+}
+
+// Check we implement the interface
+var _ region.RegionNumerics = PerturbRegionProxy{}
+
+func (proxy PerturbRegionProxy) ClaimExtrinsics() {
+	proxy.PerturbRegionNumerics.Region = proxy.LocalRegion
+}
+
+func (proxy PerturbRegionProxy) Extrinsically(f func()) {
+	old := proxy.PerturbRegionNumerics.Region
+	proxy.ClaimExtrinsics()
+	f()
+	proxy.PerturbRegionNumerics.Region = old
+}
+
+type PerturbSequenceProxy struct {
+	*perturbsequence.PerturbSequenceNumerics
+	LocalRegion perturbRegion
+}
+
+func (proxy PerturbSequenceProxy) ClaimExtrinsics() {
+	proxy.PerturbSequenceNumerics.SubImage(proxy.LocalRegion.rect().Intersect(proxy.TileRect()))
+}
+
+func (proxy PerturbSequenceProxy) Extrinsically(f func()) {
+	cmin := complex(proxy.RealMin, proxy.ImagMin)
+	cmax := complex(proxy.RealMax, proxy.ImagMax)
+
+	proxy.ClaimExtrinsics()
+	f()
+	proxy.RealMin = real(cmin)
+	proxy.ImagMin = imag(cmin)
+	proxy.RealMax = real(cmax)
+	proxy.ImagMax = imag(cmax)
+
+	proxy.RestorePicBounds()
+}
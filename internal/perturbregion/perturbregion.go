@@ -0,0 +1,243 @@
+package perturbregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/perturbsequence"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+	"image"
+	"log"
+)
+
+type perturbSubregion struct {
+	populated bool
+	children  []perturbRegion
+}
+
+type perturbRegion struct {
+	region.Region
+	topLeft     perturbbase.PerturbEscapeValue
+	topRight    perturbbase.PerturbEscapeValue
+	bottomLeft  perturbbase.PerturbEscapeValue
+	bottomRight perturbbase.PerturbEscapeValue
+	midPoint    perturbbase.PerturbEscapeValue
+}
+
+func (pr *perturbRegion) rect() image.Rectangle {
+	return image.Rect(pr.Xmin, pr.Ymin, pr.Xmax, pr.Ymax)
+}
+
+// PerturbRegionNumerics extends PerturbBaseNumerics and adds support for regions.  Region
+// corners are stored as distances from the reference point.
+type PerturbRegionNumerics struct {
+	region.RegionConfig
+	perturbbase.PerturbBaseNumerics
+	Region           perturbRegion
+	SequenceNumerics *perturbsequence.PerturbSequenceNumerics
+	subregion        perturbSubregion
+}
+
+// Check that we implement the interface
+var _ region.RegionNumerics = (*PerturbRegionNumerics)(nil)
+
+func Make(app RenderApplication) PerturbRegionNumerics {
+	return MakeShared(app, &perturbbase.Reference{})
+}
+
+// MakeShared is like Make, but shares the reference orbit and series in ref.
+func MakeShared(app RenderApplication, ref *perturbbase.Reference) PerturbRegionNumerics {
+	parent := perturbbase.MakeShared(app, ref)
+	// Share the reference orbit rather than computing it twice
+	sequence := perturbsequence.PerturbSequenceNumerics{
+		PerturbBaseNumerics: parent,
+	}
+	reg := PerturbRegionNumerics{
+		PerturbBaseNumerics: parent,
+		RegionConfig:        app.RegionConfig(),
+		SequenceNumerics:    &sequence,
+	}
+	reg.initRegion()
+	return reg
+}
+
+func (perturb *PerturbRegionNumerics) ClaimExtrinsics() {
+	// Region already present
+}
+
+func (perturb *PerturbRegionNumerics) Extrinsically(f func()) {
+	f()
+}
+
+// Return the children of this region
+func (perturb *PerturbRegionNumerics) Children() []region.RegionNumerics {
+	const childCount = 4
+	if perturb.subregion.populated {
+		nextContexts := make([]region.RegionNumerics, childCount)
+		for i, child := range perturb.subregion.children {
+			nextContexts[i] = perturb.Proxy(child)
+		}
+		return nextContexts
+	}
+	log.Panic("Region asked to provide non-existent children")
+	return nil
+}
+
+func (perturb *PerturbRegionNumerics) RegionSequence() region.ProxySequence {
+	return perturb.PerturbSequence()
+}
+
+func (perturb *PerturbRegionNumerics) PerturbSequence() PerturbSequenceProxy {
+	numerics := perturb.SequenceNumerics
+	if perturb.Concurrent {
+		copied := *numerics
+		numerics = &copied
+	}
+	return PerturbSequenceProxy{
+		LocalRegion:             perturb.Region,
+		PerturbSequenceNumerics: numerics,
+	}
+}
+
+func (perturb *PerturbRegionNumerics) Proxy(region perturbRegion) PerturbRegionProxy {
+	numerics := perturb
+	if perturb.Concurrent {
+		copied := *perturb
+		copied.subregion = perturbSubregion{}
+		numerics = &copied
+	}
+	return PerturbRegionProxy{
+		LocalRegion:           region,
+		PerturbRegionNumerics: numerics,
+	}
+}
+
+func (perturb *PerturbRegionNumerics) MandelbrotPoints() []base.EscapeValue {
+	ps := perturb.Points()
+	base := make([]base.EscapeValue, len(ps))
+	for i, p := range ps {
+		base[i] = p.EscapeValue
+	}
+	return base
+}
+
+func (perturb *PerturbRegionNumerics) Split() {
+	imgchlds := perturb.Region.Split()
+
+	pchlds := make([]perturbRegion, len(imgchlds))
+
+	for i, ic := range imgchlds {
+		pchlds[i] = perturb.planeRegion(ic)
+	}
+
+	perturb.subregion = perturbSubregion{
+		populated: true,
+		children:  pchlds,
+	}
+}
+
+func (perturb *PerturbRegionNumerics) planeRegion(r region.Region) perturbRegion {
+	rmin := perturb.Xtor(r.Xmin)
+	rmax := perturb.Xtor(r.Xmax)
+	itop := perturb.Ytoi(r.Ymin)
+	ibott := perturb.Ytoi(r.Ymax)
+
+	rmid := (rmin + rmax) / 2
+	imid := (itop + ibott) / 2
+
+	preg := perturbRegion{}
+	preg.topLeft = perturb.Escape(complex(rmin, itop))
+	preg.topRight = perturb.Escape(complex(rmax, itop))
+	preg.bottomLeft = perturb.Escape(complex(rmin, ibott))
+	preg.bottomRight = perturb.Escape(complex(rmax, ibott))
+	preg.midPoint = perturb.Escape(complex(rmid, imid))
+	preg.Region = r
+
+	return preg
+}
+
+func (perturb *PerturbRegionNumerics) Rect() image.Rectangle {
+	return perturb.Region.rect()
+}
+
+// Return EscapeValue
+// Does not check if the region's Points have been evaluated
+func (perturb *PerturbRegionNumerics) RegionMember() base.EscapeValue {
+	return perturb.Region.topLeft.EscapeValue
+}
+
+func (perturb *PerturbRegionNumerics) Points() []perturbbase.PerturbEscapeValue {
+	region := perturb.Region
+	return []perturbbase.PerturbEscapeValue{
+		region.topLeft,
+		region.topRight,
+		region.bottomLeft,
+		region.bottomRight,
+		region.midPoint,
+	}
+}
+
+func (perturb *PerturbRegionNumerics) SampleDivs() (<-chan uint32, chan<- bool) {
+	done := make(chan bool, 1)
+	idivch := make(chan uint32)
+
+	go perturb.sample(idivch, done)
+
+	return idivch, done
+}
+
+func (perturb *PerturbRegionNumerics) sample(idivch chan<- uint32, done <-chan bool) {
+	complete := func(idiv uint32) bool {
+		select {
+		case <-done:
+			close(idivch)
+			return true
+		default:
+			idivch <- idiv
+			return false
+		}
+	}
+
+	eval := func(r, i float64) uint32 {
+		p := perturb.Escape(complex(r, i))
+		return p.InvDiv
+	}
+
+	// Provide the samples we already have
+	for _, p := range perturb.Points() {
+		if complete(p.InvDiv) {
+			return
+		}
+	}
+
+	// Generate samples
+	tl := perturb.Region.topLeft.C
+	br := perturb.Region.bottomRight.C
+	count := perturb.Samples
+	fCount := float64(count)
+	rmin := real(tl)
+	rmax := real(br)
+	imin := imag(br)
+	imax := imag(tl)
+	width := rmax - rmin
+	height := imax - imin
+	rUnit := width / fCount
+	iUnit := height / fCount
+	rdown := rmax
+	for i := uint(0); i < count; i++ {
+		rdown -= rUnit
+		idown := imax
+		for j := uint(0); j < count; j++ {
+			idown -= iUnit
+			if complete(eval(rdown, idown)) {
+				return
+			}
+		}
+	}
+	close(idivch)
+}
+
+func (perturb *PerturbRegionNumerics) initRegion() {
+	reg := region.InitRegion(&perturb.BaseNumerics)
+
+	perturb.Region = perturb.planeRegion(reg)
+}
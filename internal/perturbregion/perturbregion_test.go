@@ -0,0 +1,78 @@
+package perturbregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+	"image"
+	"testing"
+)
+
+const prec = 53
+
+func makeMock() *MockRenderApplication {
+	mock := &MockRenderApplication{}
+	mock.Base = base.BaseConfig{DivergeLimit: 4.0, IterateLimit: 100}
+	mock.PictureWidth = 100
+	mock.PictureHeight = 100
+	mock.RegConfig = region.RegionConfig{Samples: 4, CollapseSize: 4}
+	mock.Prec = prec
+	mock.UserMin = bigbase.MakeBigComplex(-2.0, -1.0, prec)
+	mock.UserMax = bigbase.MakeBigComplex(0.0, 1.0, prec)
+	return mock
+}
+
+func TestMake(t *testing.T) {
+	mock := makeMock()
+	numerics := Make(mock)
+
+	if !(mock.TRegionConfig && mock.TBigUserCoords && mock.TPrecision) {
+		t.Error("Expected methods not called on mock", mock)
+	}
+
+	expect := image.Rect(0, 0, 100, 100)
+	if actual := numerics.Rect(); actual != expect {
+		t.Error("Expected region", expect, "but received", actual)
+	}
+
+	if numerics.SequenceNumerics.Orbit != numerics.Orbit {
+		t.Error("Expected sequence numerics to share the reference orbit")
+	}
+}
+
+func TestChildren(t *testing.T) {
+	numerics := Make(makeMock())
+	numerics.Split()
+	children := numerics.Children()
+
+	expect := []image.Rectangle{
+		image.Rect(0, 0, 50, 50),
+		image.Rect(50, 0, 100, 50),
+		image.Rect(0, 50, 50, 100),
+		image.Rect(50, 50, 100, 100),
+	}
+
+	for i, child := range children {
+		child.ClaimExtrinsics()
+		if actual := child.Rect(); actual != expect[i] {
+			t.Error("Expected child", i, "at", expect[i], "but received", actual)
+		}
+	}
+}
+
+func TestSampleDivs(t *testing.T) {
+	numerics := Make(makeMock())
+	idivs, done := numerics.SampleDivs()
+
+	count := 0
+	for range idivs {
+		count++
+	}
+	done <- true
+
+	// Five region points, plus the sample grid
+	const expect = 5 + (4 * 4)
+	if count != expect {
+		t.Error("Expected", expect, "samples but received", count)
+	}
+}
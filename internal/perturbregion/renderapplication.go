@@ -0,0 +1,15 @@
+package perturbregion
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/region"
+)
+
+type RenderApplication interface {
+	bigbase.BigCoordProvider
+	region.RegionProvider
+	base.RenderApplication
+	perturbbase.SeriesProvider
+}
@@ -0,0 +1,53 @@
+package perturbsequence
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/sequence"
+)
+
+type PerturbSequenceNumerics struct {
+	perturbbase.PerturbBaseNumerics
+}
+
+// Check we implement interface
+var _ sequence.SequenceNumerics = (*PerturbSequenceNumerics)(nil)
+
+func Make(app perturbbase.RenderApplication) PerturbSequenceNumerics {
+	return MakeShared(app, &perturbbase.Reference{})
+}
+
+// MakeShared is like Make, but shares the reference orbit and series in ref.
+func MakeShared(app perturbbase.RenderApplication, ref *perturbbase.Reference) PerturbSequenceNumerics {
+	return PerturbSequenceNumerics{
+		PerturbBaseNumerics: perturbbase.MakeShared(app, ref),
+	}
+}
+
+func (psn *PerturbSequenceNumerics) Sequence() []base.PixelMember {
+	ileft, itop := psn.PictureMin()
+	iright, ibott := psn.PictureMax()
+	iterlim := psn.IterateLimit
+
+	area := (iright - ileft) * (ibott - itop)
+	out := make([]base.PixelMember, area)
+
+	count := 0
+	for i := ileft; i < iright; i++ {
+		if psn.Cancelled() {
+			break
+		}
+		column := count
+		for j := itop; j < ibott; j++ {
+			if !psn.Lattice.Contains(i, j) {
+				continue
+			}
+			member := psn.CreateMandelbrot(psn.PixelPosition(i, j))
+			member.Mandelbrot(iterlim)
+			out[count] = base.PixelMember{I: i, J: j, Member: member.EscapeValue}
+			count++
+		}
+		psn.Tracker.AddPixels(count - column)
+	}
+	return out[:count]
+}
@@ -0,0 +1,50 @@
+package perturbsequence
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"github.com/johnny-morrice/godelbrot/internal/bigsequence"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"testing"
+)
+
+func TestPerturbMandelbrotSequence(t *testing.T) {
+	const prec = 53
+	const iterLimit = 100
+
+	app := &perturbbase.MockRenderApplication{}
+	app.Base = base.BaseConfig{DivergeLimit: 4.0, IterateLimit: iterLimit}
+	app.PictureWidth = 10
+	app.PictureHeight = 10
+	app.Prec = prec
+	app.UserMin = bigbase.MakeBigComplex(-2.0, -1.0, prec)
+	app.UserMax = bigbase.MakeBigComplex(0.5, 1.0, prec)
+	numerics := Make(app)
+	out := numerics.Sequence()
+
+	const expectedCount = 100
+	actualCount := len(out)
+
+	if expectedCount != actualCount {
+		t.Fatal("Expected", expectedCount, "members but there were", actualCount)
+	}
+
+	big := bigsequence.Make(app)
+	expect := big.Sequence()
+
+	mismatch := 0
+	for i, px := range out {
+		ex := expect[i]
+		if px.I != ex.I || px.J != ex.J {
+			t.Fatal("Expected pixel", ex.I, ex.J, "but received", px.I, px.J)
+		}
+		if px.Member.InvDiv != ex.Member.InvDiv {
+			mismatch++
+		}
+	}
+
+	// Rounding differs between the two systems, so allow the odd boundary pixel to differ
+	if mismatch > 2 {
+		t.Error("Perturbation differed from big.Float on", mismatch, "pixels")
+	}
+}
@@ -11,7 +11,8 @@ func DrawUniform(context paint.DrawingContext, region RegionNumerics) {
 	member := region.RegionMember()
 	color := context.Colors().Color(member)
 	uniform := image.NewUniform(color)
-	rect := region.Rect()
+	rect := region.Rect().Intersect(context.Picture().Bounds())
 
 	draw.Draw(context.Picture(), rect, uniform, image.ZP, draw.Src)
+	paint.RecordRect(context, member, rect)
 }
@@ -35,10 +35,10 @@ type MockNumerics struct {
 	AppCollapseSize int
 }
 
-func (mock *MockNumerics) SampleDivs() (<-chan uint8, chan<- bool) {
+func (mock *MockNumerics) SampleDivs() (<-chan uint32, chan<- bool) {
 	mock.TSampleDivs = true
 	done := make(chan bool, 1)
-	idivch := make(chan uint8, 1)
+	idivch := make(chan uint32, 1)
 
 	go func() {
 		for _, p := range mock.MandelbrotPoints() {
@@ -11,12 +11,16 @@ type Subdivider interface {
 	Rect() image.Rectangle
 	Split()
 	MandelbrotPoints() []base.EscapeValue
-	SampleDivs() (<-chan uint8, chan<- bool)
+	SampleDivs() (<-chan uint32, chan<- bool)
 	RegionMember() base.EscapeValue
 	RegionSequence() ProxySequence
 }
 
-// RegionNumerics provides rendering calculations for the "region" render strategy.
+// RegionNumerics provides rendering calculations for the "region" render strategy.  The
+// numerics of a region are shared with its children and its sequence, unless the RegionConfig
+// is Concurrent.  Then each has its own copy of the numerics, so that regions may be subdivided
+// and drawn at once.  Copies share big number values, which is safe because the numerics
+// replace those values rather than Set them.
 type RegionNumerics interface {
 	base.OpaqueProxyFlyweight
 	Subdivider
@@ -38,6 +42,16 @@ func RenderSequenceRegion(reg RegionNumerics, ctx draw.DrawingContext) {
 	})
 }
 
+// SupersampleRegion is analogous to RenderSequenceRegion, but averages each pixel over the
+// subpixel offsets.
+func SupersampleRegion(reg RegionNumerics, ctx draw.DrawingContext, subs []base.SubPixel, jitter bool) {
+	reg.ClaimExtrinsics()
+	seq := reg.RegionSequence()
+	seq.Extrinsically(func() {
+		sequence.SupersampleSequence(seq, ctx, subs, jitter)
+	})
+}
+
 // SequenceCollapse is analogous to RenderSequentialRegion, but it returns the Mandelbrot render
 // results rather than drawing them to the image.
 func SequenceCollapse(num RegionNumerics) []base.PixelMember {
@@ -51,9 +65,9 @@ func SequenceCollapse(num RegionNumerics) []base.PixelMember {
 
 // Subdivide takes a RegionNumerics and tries to split the region into subregions.  It returns true
 // if the subdivision occurred.  The subdivision won't occur if the region is Uniform or in an area
-// where glitches are likely.
-func Subdivide(reg RegionNumerics) bool {
-	if !Uniform(reg) {
+// where glitches are likely.  With interior detail, a region inside the set is never uniform.
+func Subdivide(reg RegionNumerics, interiorDetail bool) bool {
+	if !Uniform(reg) || (interiorDetail && reg.RegionMember().InSet) {
 		reg.Split()
 		return true
 	}
@@ -90,12 +104,12 @@ type Region struct {
 	Ymax int
 }
 
+// InitRegion returns the region covering the whole picture, even when only a tile is rendered,
+// so that a tile is divided into the same regions as the whole picture.
 func InitRegion(bn *base.BaseNumerics) Region {
 	r := Region{}
-	r.Xmin = bn.PicXMin
-	r.Xmax = bn.PicXMax
-	r.Ymin = bn.PicYMin
-	r.Ymax = bn.PicYMax
+	r.Xmax = int(bn.WholeWidth)
+	r.Ymax = int(bn.WholeHeight)
 
 	return r
 }
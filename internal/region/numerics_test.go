@@ -44,7 +44,7 @@ func TestSequenceCollapse(t *testing.T) {
 }
 
 func TestSubdivide(t *testing.T) {
-	const iterateLimit uint8 = 200
+	const iterateLimit uint32 = 200
 	const glitchSamples uint = 10
 	uniReg := &MockNumerics{Path: UniformPath}
 	// Collapsed regions shouldn't care about subdivision
@@ -61,7 +61,7 @@ func TestSubdivide(t *testing.T) {
 	actual := make([]bool, len(regions))
 
 	for i, reg := range regions {
-		actual[i] = Subdivide(reg)
+		actual[i] = Subdivide(reg, false)
 	}
 
 	// Results for uniform region
@@ -85,6 +85,18 @@ func TestSubdivide(t *testing.T) {
 	}
 }
 
+func TestSubdivideInteriorDetail(t *testing.T) {
+	// The uniform mock region lies inside the set
+	uniReg := &MockNumerics{Path: UniformPath}
+
+	if !Subdivide(uniReg, true) {
+		t.Error("Expected positive Subdivide return for region inside the set with interior detail")
+	}
+	if !uniReg.TSplit {
+		t.Error("Expected methods were not called on interior region:", uniReg)
+	}
+}
+
 func TestUniform(t *testing.T) {
 	uniform := &MockNumerics{Path: UniformPath}
 	collapse := &MockNumerics{Path: CollapsePath}
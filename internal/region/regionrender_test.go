@@ -8,7 +8,7 @@ import (
 )
 
 func TestNewRegionRenderer(t *testing.T) {
-	const iterateLimit uint8 = 200
+	const iterateLimit uint32 = 200
 	const collapse uint = 40
 	expectedPic := image.NewNRGBA(image.ZR)
 	context := &draw.MockDrawingContext{
@@ -35,7 +35,7 @@ func TestNewRegionRenderer(t *testing.T) {
 	}
 
 	mockOkay := mock.TRegionNumericsFactory && mock.TDrawingContext
-	mockOkay = mockOkay && mock.TRegionConfig
+	mockOkay = mockOkay && mock.TRegionConfig && mock.TBaseConfig
 
 	if !mockOkay {
 		t.Error("Expected methods not called on mock")
@@ -43,7 +43,7 @@ func TestNewRegionRenderer(t *testing.T) {
 }
 
 func TestRender(t *testing.T) {
-	const iterateLimit uint8 = 200
+	const iterateLimit uint32 = 200
 	const collapseSize int = 40
 	expectedPic := image.NewNRGBA(image.ZR)
 	mockPalette := &draw.MockPalette{}
@@ -107,8 +107,67 @@ func TestRender(t *testing.T) {
 	}
 }
 
+func TestRenderProgressive(t *testing.T) {
+	const collapseSize int = 40
+	expectedPic := image.NewNRGBA(image.ZR)
+	context := &draw.MockDrawingContext{
+		Pic: expectedPic,
+		Col: &draw.MockPalette{},
+	}
+	collapseSequence := &MockProxySequence{}
+	uniform := &MockNumerics{
+		Path:            UniformPath,
+		AppCollapseSize: collapseSize,
+	}
+	collapse := &MockNumerics{
+		Path:            CollapsePath,
+		MockSequence:    collapseSequence,
+		AppCollapseSize: collapseSize,
+	}
+	mockNumerics := &MockNumerics{
+		Path:            SubdividePath,
+		MockChildren:    []*MockNumerics{uniform, collapse},
+		MockSequence:    &MockProxySequence{},
+		AppCollapseSize: collapseSize,
+	}
+	renderer := RegionRenderStrategy{
+		factory:      &MockFactory{Numerics: mockNumerics},
+		context:      context,
+		regionConfig: RegionConfig{CollapseSize: uint(collapseSize)},
+	}
+
+	passes := 0
+	progress := func(pic *image.NRGBA) {
+		passes++
+		if pic != expectedPic {
+			t.Error("Expected pic differed from progress pic:", pic)
+		}
+		if !uniform.TRect || collapseSequence.TSequence {
+			t.Error("Expected progress after uniform regions and before detail")
+		}
+	}
+
+	actualPic, err := renderer.RenderProgressive(progress)
+
+	if actualPic != expectedPic {
+		t.Error("Expected pic differed from actual:", actualPic)
+	}
+
+	if err != nil {
+		t.Error("Unexpeced error in render:", err)
+	}
+
+	if passes != 1 {
+		t.Error("Expected one progress call but received", passes)
+	}
+
+	if !collapseSequence.TSequence {
+		t.Error("Expected methods not called on collapsed sequence numerics:", collapseSequence)
+	}
+}
+
 func TestSubdivideRegions(t *testing.T) {
-	const iterateLimit uint8 = 200
+	const iterateLimit uint32 = 200
 	const collapseSize = 40
 	uniform := newMockNumerics(UniformPath, collapseSize)
 	collapse := newMockNumerics(CollapsePath, collapseSize)
@@ -154,3 +213,51 @@ func TestSubdivideRegions(t *testing.T) {
 		t.Error("Expected methods not called on collapsed region:", collapse)
 	}
 }
+
+func TestSubdivideRegionsTile(t *testing.T) {
+	const collapseSize = 40
+	mock := &MockNumerics{
+		Path:            SubdividePath,
+		MockChildren:    []*MockNumerics{newMockNumerics(UniformPath, collapseSize)},
+		AppCollapseSize: collapseSize,
+	}
+	renderer := RegionRenderStrategy{
+		regionConfig: RegionConfig{CollapseSize: collapseSize},
+		tile:         image.Rect(1000, 1000, 1100, 1100),
+	}
+
+	uniform, collapse := renderer.SubdivideRegions(mock)
+
+	if len(uniform) != 0 || len(collapse) != 0 {
+		t.Error("Expected no regions outside the tile, but received", uniform, collapse)
+	}
+
+	if mock.TSplit {
+		t.Error("Expected region outside the tile to be left whole:", mock)
+	}
+}
+
+func TestSubdivideRegionsCancelled(t *testing.T) {
+	const collapseSize = 40
+	mock := &MockNumerics{
+		Path:            SubdividePath,
+		MockChildren:    []*MockNumerics{newMockNumerics(UniformPath, collapseSize)},
+		AppCollapseSize: collapseSize,
+	}
+	done := make(chan struct{})
+	close(done)
+	renderer := RegionRenderStrategy{
+		regionConfig: RegionConfig{CollapseSize: collapseSize},
+		done:         done,
+	}
+
+	uniform, collapse := renderer.SubdivideRegions(mock)
+
+	if len(uniform) != 0 || len(collapse) != 0 {
+		t.Error("Expected no regions after cancellation, but received", uniform, collapse)
+	}
+
+	if mock.TClaimExtrinsics {
+		t.Error("Expected cancelled subdivision to leave region alone:", mock)
+	}
+}
@@ -4,73 +4,228 @@ import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
 	"github.com/johnny-morrice/godelbrot/internal/draw"
 	"image"
+	"sync"
 )
 
 type RegionRenderStrategy struct {
 	factory      RegionNumericsFactory
 	context      draw.DrawingContext
 	regionConfig RegionConfig
+	samples      uint
+	jitter       bool
+	jobs         uint16
+	tile         image.Rectangle
+	done         <-chan struct{}
+	tracker      *base.Tracker
 }
 
 func Make(app RenderApplication) *RegionRenderStrategy {
+	config := app.BaseConfig()
 	return &RegionRenderStrategy{
 		factory:      app.RegionNumericsFactory(),
 		context:      app.DrawingContext(),
 		regionConfig: app.RegionConfig(),
+		samples:      config.PixelSamples,
+		jitter:       config.JitterSamples,
+		jobs:         config.Jobs,
+		tile:         config.Tile,
+		done:         config.Done,
+		tracker:      config.Tracker,
 	}
 }
 
 // The RegionRenderStrategy implements RenderNumerics with this method that
-// draws the Mandelbrot set uses a "similar rectangles" optimization
+// draws the Mandelbrot set uses a "similar rectangles" optimization.  Each
+// stage of the render is shared between as many goroutines as there are jobs.
 func (renderer RegionRenderStrategy) Render() (*image.NRGBA, error) {
+	return renderer.RenderProgressive(func(*image.NRGBA) {})
+}
+
+// RenderProgressive draws the uniform regions, then calls progress with the
+// picture before drawing the detail of the small regions.  A cancelled render
+// stops early, leaving the picture unfinished.
+func (renderer RegionRenderStrategy) RenderProgressive(progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	tracker := renderer.tracker
+	bounds := renderer.context.Picture().Bounds()
+	tracker.Begin(base.SubdividePhase, area(bounds), 1)
+
 	// The numerics system is by default a region covering the whole image
 	initialRegion := renderer.factory.Build()
 	uniformRegions, smallRegions := renderer.SubdivideRegions(initialRegion)
 
-	// Draw uniform regions first
+	// Small regions are computed once for each sample
+	subs := base.SubPixels(renderer.samples)
+	total := 0
 	for _, region := range uniformRegions {
 		region.ClaimExtrinsics()
-		DrawUniform(renderer.context, region)
+		total += area(region.Rect().Intersect(bounds))
 	}
-
-	// Add detail from the small regions next
 	for _, region := range smallRegions {
-		RenderSequenceRegion(region, renderer.context)
+		region.ClaimExtrinsics()
+		total += area(region.Rect().Intersect(bounds)) * len(subs)
 	}
 
+	// Draw uniform regions first.  These are never supersampled.
+	tracker.Begin(base.UniformPhase, total, len(uniformRegions)+len(smallRegions))
+	renderer.each(uniformRegions, func(region RegionNumerics) {
+		region.ClaimExtrinsics()
+		DrawUniform(renderer.context, region)
+		tracker.AddPixels(area(region.Rect().Intersect(bounds)))
+		tracker.AddRegions(-1)
+	})
+
+	if base.Cancelled(renderer.done) {
+		return renderer.context.Picture(), nil
+	}
+	progress(renderer.context.Picture())
+
+	// Add detail from the small regions next.  The numerics count the pixels.
+	tracker.Begin(base.SequencePhase, total, len(smallRegions))
+	renderer.each(smallRegions, func(region RegionNumerics) {
+		if len(subs) > 1 {
+			SupersampleRegion(region, renderer.context, subs, renderer.jitter)
+		} else {
+			RenderSequenceRegion(region, renderer.context)
+		}
+		tracker.AddRegions(-1)
+	})
+
 	return renderer.context.Picture(), nil
 }
 
 func (renderer RegionRenderStrategy) SubdivideRegions(whole RegionNumerics) ([]RegionNumerics, []RegionNumerics) {
-	// Lots of preallocated space for regions and region pointers
-	completeRegions := make([]RegionNumerics, 0, base.AllocMedium)
-	smallRegions := make([]RegionNumerics, 0, base.AllocMedium)
-	splittingRegions := make([]RegionNumerics, 1, base.AllocMedium)
-	collapseBound := int(renderer.regionConfig.CollapseSize)
-
-	// Split regions
-	splittingRegions[0] = whole
-	for i := 0; i < len(splittingRegions); i++ {
-		splitee := splittingRegions[i]
-
-		splitee.ClaimExtrinsics()
-		// There are three things that can happen to a region...
-		//
-		// A. The region can be so small that we divide no further
-		if Collapse(splitee, collapseBound) {
-			smallRegions = append(smallRegions, splitee)
-		} else {
-			// If the region is not too small, two things can happen
-			// B. The region needs subdivided because it covers distinct parts of the plane
-			divided := Subdivide(splitee)
-			if divided {
-				splittingRegions = append(splittingRegions, splitee.Children()...)
-				// C. The region need not be divided
-			} else {
-				completeRegions = append(completeRegions, splitee)
+	sub := subdivision{
+		// Lots of preallocated space for regions and region pointers
+		complete: make([]RegionNumerics, 0, base.AllocMedium),
+		small:    make([]RegionNumerics, 0, base.AllocMedium),
+		// The calling goroutine is the first worker
+		workers:        make(chan bool, renderer.workers()-1),
+		collapseBound:  int(renderer.regionConfig.CollapseSize),
+		interiorDetail: renderer.regionConfig.InteriorDetail,
+		tile:           renderer.tile,
+		done:           renderer.done,
+		tracker:        renderer.tracker,
+	}
+
+	sub.split(whole)
+	sub.wait.Wait()
+
+	return sub.complete, sub.small
+}
+
+// each calls f on every region, sharing the regions between the jobs, until
+// the render is cancelled
+func (renderer RegionRenderStrategy) each(regions []RegionNumerics, f func(RegionNumerics)) {
+	jobs := renderer.workers()
+	if jobs == 1 {
+		for _, region := range regions {
+			if base.Cancelled(renderer.done) {
+				break
+			}
+			f(region)
+		}
+		return
+	}
+
+	work := make(chan RegionNumerics)
+	wg := sync.WaitGroup{}
+	wg.Add(jobs)
+	for i := 0; i < jobs; i++ {
+		go func() {
+			defer wg.Done()
+			for region := range work {
+				f(region)
 			}
+		}()
+	}
+	for _, region := range regions {
+		if base.Cancelled(renderer.done) {
+			break
 		}
+		work <- region
+	}
+	close(work)
+	wg.Wait()
+}
+
+func (renderer RegionRenderStrategy) workers() int {
+	if renderer.jobs == 0 {
+		return 1
 	}
+	return int(renderer.jobs)
+}
+
+// subdivision collects the regions found by splitting the picture.
+type subdivision struct {
+	sync.Mutex
+	complete []RegionNumerics
+	small    []RegionNumerics
+
+	// Holds a value for each goroutine working besides the first
+	workers chan bool
+	wait    sync.WaitGroup
+
+	collapseBound  int
+	interiorDetail bool
+	// Part of the picture rendered, where the empty rectangle means the whole picture.  Regions
+	// outside the tile are not drawn.
+	tile image.Rectangle
+
+	// Closed when the render is cancelled, which stops subdivision
+	done <-chan struct{}
+	// Counts the regions found
+	tracker *base.Tracker
+}
+
+func (sub *subdivision) split(splitee RegionNumerics) {
+	if base.Cancelled(sub.done) {
+		return
+	}
+
+	splitee.ClaimExtrinsics()
+	if !sub.tile.Empty() && !splitee.Rect().Overlaps(sub.tile) {
+		sub.tracker.AddRegions(-1)
+		return
+	}
+
+	// There are three things that can happen to a region...
+	//
+	// A. The region can be so small that we divide no further
+	if Collapse(splitee, sub.collapseBound) {
+		sub.Lock()
+		sub.small = append(sub.small, splitee)
+		sub.Unlock()
+		return
+	}
+
+	// If the region is not too small, two things can happen
+	// B. The region needs subdivided because it covers distinct parts of the plane
+	if Subdivide(splitee, sub.interiorDetail) {
+		children := splitee.Children()
+		sub.tracker.AddRegions(len(children) - 1)
+		for _, child := range children {
+			// Each region has its own numerics, so children may be split in parallel
+			select {
+			case sub.workers <- true:
+				sub.wait.Add(1)
+				go func(child RegionNumerics) {
+					defer sub.wait.Done()
+					sub.split(child)
+					<-sub.workers
+				}(child)
+			default:
+				sub.split(child)
+			}
+		}
+		return
+	}
+
+	// C. The region need not be divided
+	sub.Lock()
+	sub.complete = append(sub.complete, splitee)
+	sub.Unlock()
+}
 
-	return completeRegions, smallRegions
+func area(rect image.Rectangle) int {
+	return rect.Dx() * rect.Dy()
 }
@@ -24,4 +24,8 @@ type RenderApplication interface {
 type RegionConfig struct {
 	Samples      uint
 	CollapseSize uint
+	// Points inside the set are coloured individually, so regions inside the set are not uniform
+	InteriorDetail bool
+	// Regions are subdivided and drawn by several jobs at once
+	Concurrent bool
 }
@@ -8,6 +8,10 @@ import (
 type MockNumerics struct {
 	TSequence bool
 	TSubImage bool
+	TOffset   bool
+
+	TPictureLattice bool
+	TPictureJitter  bool
 
 	PointCount int
 }
@@ -24,3 +28,15 @@ func (mn *MockNumerics) Sequence() []base.PixelMember {
 func (mn *MockNumerics) SubImage(rect image.Rectangle) {
 	mn.TSubImage = true
 }
+
+func (mn *MockNumerics) Offset(dx, dy float64) {
+	mn.TOffset = true
+}
+
+func (mn *MockNumerics) PictureLattice(lattice base.Lattice) {
+	mn.TPictureLattice = true
+}
+
+func (mn *MockNumerics) PictureJitter(jitter base.Jitter) {
+	mn.TPictureJitter = true
+}
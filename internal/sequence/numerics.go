@@ -3,11 +3,20 @@ package sequence
 import (
 	"github.com/johnny-morrice/godelbrot/internal/base"
 	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"image"
 )
 
 // SequentialNumerics provides sequential (column-wise) rendering calculations
 type SequenceNumerics interface {
 	Sequence() []base.PixelMember
+	// SubImage restricts rendering to part of the picture
+	SubImage(rect image.Rectangle)
+	// Offset moves the sample point of every pixel by a fraction of a pixel
+	Offset(dx, dy float64)
+	// PictureLattice restricts rendering to the pixels on the lattice
+	PictureLattice(lattice base.Lattice)
+	// PictureJitter moves the sample point of each pixel within its cell of the sample grid
+	PictureJitter(jitter base.Jitter)
 }
 
 func ImageSequence(sn SequenceNumerics, context draw.DrawingContext) {
@@ -17,6 +26,33 @@ func ImageSequence(sn SequenceNumerics, context draw.DrawingContext) {
 	}
 }
 
+// SupersampleSequence draws the average colour of several samples in each pixel.  With jitter,
+// each sample is moved to a random point within its cell of the sample grid.
+func SupersampleSequence(sn SequenceNumerics, context draw.DrawingContext, subs []base.SubPixel, jitter bool) {
+	buf := draw.SampleBuffer{}
+	for s, sub := range subs {
+		sn.Offset(sub.X, sub.Y)
+		if jitter {
+			sn.PictureJitter(base.MakeJitter(uint(len(subs)), s))
+		}
+		members := sn.Sequence()
+		buf.Add(context.Colors(), members)
+		draw.RecordSample(context, members, s)
+		sn.Offset(-sub.X, -sub.Y)
+	}
+	sn.PictureJitter(base.Jitter{})
+	buf.Draw(context.Picture())
+}
+
+// LatticeSequence draws each sampled pixel as a block reaching to the next sample
+func LatticeSequence(sn SequenceNumerics, context draw.DrawingContext, lattice base.Lattice) {
+	bounds := context.Picture().Bounds()
+	for _, point := range sn.Sequence() {
+		block := lattice.Block(point.I, point.J).Intersect(bounds)
+		draw.DrawBlock(context, point, block)
+	}
+}
+
 func Capture(sn SequenceNumerics) []base.PixelMember {
 	return sn.Sequence()
 }
@@ -1,6 +1,7 @@
 package sequence
 
 import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
 	"github.com/johnny-morrice/godelbrot/internal/draw"
 	"testing"
 )
@@ -16,3 +17,15 @@ func TestImageSequence(t *testing.T) {
 			"all this test really does is prove that the mechanism works without crashing out")
 	}
 }
+
+func TestSupersampleSequence(t *testing.T) {
+	const iterateLimit = 10
+	context := draw.NewMockDrawingContext(iterateLimit)
+	numerics := &MockNumerics{}
+	subs := base.SubPixels(4)
+	SupersampleSequence(numerics, context, subs, true)
+
+	if !(numerics.TOffset && numerics.TPictureJitter && numerics.TSequence && context.TColors && context.TPicture) {
+		t.Error("Expected methods not called on mock")
+	}
+}
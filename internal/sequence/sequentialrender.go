@@ -1,25 +1,158 @@
 package sequence
 
 import (
+	"github.com/johnny-morrice/godelbrot/internal/base"
 	"github.com/johnny-morrice/godelbrot/internal/draw"
 	"image"
+	"sync"
 )
 
 type SequenceRenderStrategy struct {
+	factory  SequenceNumericsFactory
 	numerics SequenceNumerics
 	context  draw.DrawingContext
+	samples  uint
+	jitter   bool
+	jobs     uint16
+	done     <-chan struct{}
+	tracker  *base.Tracker
 }
 
 func Make(app RenderApplication) SequenceRenderStrategy {
+	config := app.BaseConfig()
+	factory := app.SequenceNumericsFactory()
 	return SequenceRenderStrategy{
-		numerics: app.SequenceNumericsFactory().Build(),
+		factory:  factory,
+		numerics: factory.Build(),
 		context:  app.DrawingContext(),
+		samples:  config.PixelSamples,
+		jitter:   config.JitterSamples,
+		jobs:     config.Jobs,
+		done:     config.Done,
+		tracker:  config.Tracker,
 	}
 }
 
 // The SequenceRenderStrategy implements RenderContext as it draws the
-// Mandelbrot set line by line
+// Mandelbrot set line by line.  With several jobs, the picture is split into
+// column bands that are drawn concurrently.  The picture may be a tile of a
+// larger image, in which case only the tile is drawn.
 func (srs SequenceRenderStrategy) Render() (*image.NRGBA, error) {
-	ImageSequence(srs.numerics, srs.context)
+	bounds := srs.context.Picture().Bounds()
+	srs.tracker.Begin(base.SequencePhase, srs.samplesIn(bounds, base.Lattice{}, true), 0)
+	srs.pass(srs.bands(), base.Lattice{}, true)
 	return srs.context.Picture(), nil
 }
+
+// RenderProgressive draws the picture in passes of increasing resolution,
+// calling progress with the picture after each pass but the last.  Each pass
+// computes only the pixels that earlier passes did not.  Supersampling is
+// left until the last pass, which then computes every pixel.  A cancelled
+// render stops early, leaving the picture unfinished.
+func (srs SequenceRenderStrategy) RenderProgressive(progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	bands := srs.bands()
+	bounds := srs.context.Picture().Bounds()
+	passes := base.ProgressivePasses(bounds.Min)
+	last := len(passes) - 1
+	if len(base.SubPixels(srs.samples)) > 1 {
+		passes[last] = base.Lattice{}
+	}
+
+	total := 0
+	for i, lattice := range passes {
+		total += srs.samplesIn(bounds, lattice, i == last)
+	}
+	srs.tracker.Begin(base.SequencePhase, total, 0)
+
+	for i, lattice := range passes {
+		if i < last {
+			srs.pass(bands, lattice, false)
+			if base.Cancelled(srs.done) {
+				break
+			}
+			progress(srs.context.Picture())
+		} else {
+			srs.pass(bands, lattice, true)
+		}
+	}
+	return srs.context.Picture(), nil
+}
+
+// samplesIn returns the number of samples taken by a pass over the rectangle
+func (srs SequenceRenderStrategy) samplesIn(rect image.Rectangle, lattice base.Lattice, supersample bool) int {
+	count := lattice.Count(rect)
+	if supersample {
+		count *= len(base.SubPixels(srs.samples))
+	}
+	return count
+}
+
+// bands returns numerics for each band of columns in the picture
+func (srs SequenceRenderStrategy) bands() []SequenceNumerics {
+	rects := ColumnBands(srs.context.Picture().Bounds(), int(srs.jobs))
+
+	// Numerics are mutable, so each band has its own.  They are built before
+	// the workers start, as building may report to the application.
+	bands := make([]SequenceNumerics, len(rects))
+	for i, rect := range rects {
+		sn := srs.numerics
+		if i > 0 {
+			sn = srs.factory.Build()
+		}
+		sn.SubImage(rect)
+		bands[i] = sn
+	}
+	return bands
+}
+
+// pass draws the pixels on the lattice, with each band drawn concurrently.
+// The blocks drawn for the samples do not overlap, even across bands.
+func (srs SequenceRenderStrategy) pass(bands []SequenceNumerics, lattice base.Lattice, supersample bool) {
+	if len(bands) == 1 {
+		srs.draw(bands[0], lattice, supersample)
+		return
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(bands))
+	for _, sn := range bands {
+		go func(sn SequenceNumerics) {
+			defer wg.Done()
+			srs.draw(sn, lattice, supersample)
+		}(sn)
+	}
+	wg.Wait()
+}
+
+func (srs SequenceRenderStrategy) draw(sn SequenceNumerics, lattice base.Lattice, supersample bool) {
+	sn.PictureLattice(lattice)
+	subs := base.SubPixels(srs.samples)
+	if supersample && len(subs) > 1 {
+		SupersampleSequence(sn, srs.context, subs, srs.jitter)
+	} else if lattice.Step > 1 {
+		LatticeSequence(sn, srs.context, lattice)
+	} else {
+		ImageSequence(sn, srs.context)
+	}
+}
+
+// ColumnBands splits the rectangle into at most n bands of columns, of nearly
+// equal width.
+func ColumnBands(rect image.Rectangle, n int) []image.Rectangle {
+	width := rect.Dx()
+	if n > width {
+		n = width
+	}
+	if n <= 1 {
+		return []image.Rectangle{rect}
+	}
+
+	bands := make([]image.Rectangle, n)
+	left := rect.Min.X
+	for i := 0; i < n; i++ {
+		right := rect.Min.X + ((width * (i + 1)) / n)
+		bands[i] = image.Rect(left, rect.Min.Y, right, rect.Max.Y)
+		left = right
+	}
+	return bands
+}
@@ -12,11 +12,12 @@ func TestMake(t *testing.T) {
 		Pic: expectedPic,
 	}
 	expectedNumerics := &MockNumerics{}
+	factory := &MockFactory{Numerics: expectedNumerics}
 	expectedRenderer := SequenceRenderStrategy{
+		factory:  factory,
 		numerics: expectedNumerics,
 		context:  context,
 	}
-	factory := &MockFactory{Numerics: expectedNumerics}
 	mock := &MockRenderApplication{
 		SequenceFactory: factory,
 	}
@@ -63,7 +64,7 @@ func TestStrategyRender(t *testing.T) {
 		t.Error("Expected a certain picture to be returned but was:", actualPic)
 	}
 
-	if !numerics.TSequence {
+	if !(numerics.TSubImage && numerics.TSequence) {
 		t.Error("Expected methods not called on numerics:", numerics)
 	}
 
@@ -71,3 +72,68 @@ func TestStrategyRender(t *testing.T) {
 		t.Error("Expected methods not called on context:", context)
 	}
 }
+
+func TestStrategyRenderProgressive(t *testing.T) {
+	const ilimit = 255
+	expectedPic := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	context := draw.NewMockDrawingContext(ilimit)
+	context.Pic = expectedPic
+	numerics := &MockNumerics{}
+
+	renderer := SequenceRenderStrategy{
+		numerics: numerics,
+		context:  context,
+	}
+
+	passes := 0
+	actualPic, err := renderer.RenderProgressive(func(pic *image.NRGBA) {
+		passes++
+		if pic != expectedPic {
+			t.Error("Expected a certain picture to be passed to progress but was:", pic)
+		}
+	})
+
+	if err != nil {
+		t.Error("Unexpected error in render")
+	}
+
+	if actualPic != expectedPic {
+		t.Error("Expected a certain picture to be returned but was:", actualPic)
+	}
+
+	if passes != 3 {
+		t.Error("Expected 3 progress calls but received", passes)
+	}
+
+	if !(numerics.TSubImage && numerics.TPictureLattice && numerics.TSequence) {
+		t.Error("Expected methods not called on numerics:", numerics)
+	}
+}
+
+func TestColumnBands(t *testing.T) {
+	rect := image.Rect(10, 5, 20, 15)
+
+	whole := ColumnBands(rect, 1)
+	if len(whole) != 1 || whole[0] != rect {
+		t.Error("Expected one band covering", rect, "but received:", whole)
+	}
+
+	bands := ColumnBands(rect, 3)
+	expect := []image.Rectangle{
+		image.Rect(10, 5, 13, 15),
+		image.Rect(13, 5, 16, 15),
+		image.Rect(16, 5, 20, 15),
+	}
+	if len(bands) != len(expect) {
+		t.Fatal("Expected", len(expect), "bands but received:", bands)
+	}
+	for i, band := range bands {
+		if band != expect[i] {
+			t.Error("Expected band", expect[i], "but received:", band)
+		}
+	}
+
+	if narrow := ColumnBands(image.Rect(0, 0, 2, 2), 8); len(narrow) != 2 {
+		t.Error("Expected no more bands than columns, but received:", narrow)
+	}
+}
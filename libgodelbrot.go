@@ -1,13 +1,30 @@
 package godelbrot
 
 import (
+	"context"
 	"image"
 )
 
 func Render(info *Info) (*image.NRGBA, error) {
-	context, err := MakeRenderer(info)
+	return RenderContext(context.Background(), info)
+}
+
+// RenderContext renders until done, or until ctx is cancelled, when it returns ctx.Err().
+func RenderContext(ctx context.Context, info *Info) (*image.NRGBA, error) {
+	renderer, err := MakeRenderer(info)
+	if err == nil {
+		return renderer.RenderContext(ctx)
+	} else {
+		return nil, err
+	}
+}
+
+// RenderProgressive renders in passes of increasing detail, calling progress with a snapshot
+// of the picture after each pass but the last.  It stops when ctx is cancelled.
+func RenderProgressive(ctx context.Context, info *Info, progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	renderer, err := MakeRenderer(info)
 	if err == nil {
-		return context.Render()
+		return renderer.RenderProgressive(ctx, progress)
 	} else {
 		return nil, err
 	}
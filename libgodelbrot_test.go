@@ -1,9 +1,15 @@
 package godelbrot
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"math"
 	"testing"
 
 	"github.com/johnny-morrice/godelbrot/config"
+	"github.com/johnny-morrice/godelbrot/internal/perturbsequence"
 )
 
 func TestConfigure(t *testing.T) {
@@ -27,3 +33,618 @@ func TestConfigure(t *testing.T) {
 		t.Error("Did not expect RenderContext after blank construction.")
 	}
 }
+
+func TestSequenceRenderJobs(t *testing.T) {
+	testRenderJobs(t, config.SequenceRenderMode)
+}
+
+func TestRegionRenderJobs(t *testing.T) {
+	testRenderJobs(t, config.RegionRenderMode)
+}
+
+// testRenderJobs checks that a render is unchanged by sharing it between several jobs
+func testRenderJobs(t *testing.T, renderer config.RenderMode) {
+	modes := []config.NumericsMode{
+		config.NativeNumericsMode,
+		config.BigFloatNumericsMode,
+		config.PerturbationNumericsMode,
+		config.DoubleDoubleNumericsMode,
+		config.FixedPointNumericsMode,
+	}
+	for _, mode := range modes {
+		pictures := make([]image.Image, 2)
+		for i, jobs := range []uint16{1, 3} {
+			req := DefaultRequest()
+			req.Renderer = renderer
+			req.Numerics = mode
+			req.Jobs = jobs
+			req.ImageWidth = 50
+			req.ImageHeight = 40
+			req.IterateLimit = 500
+			req.RealMin = "-0.743647"
+			req.RealMax = "-0.743627"
+			req.ImagMin = "0.131817"
+			req.ImagMax = "0.131837"
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pictures[i], err = Render(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		bounds := pictures[0].Bounds()
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				if pictures[0].At(x, y) != pictures[1].At(x, y) {
+					t.Fatal("Numerics", mode, "rendered differently with several jobs at", x, y)
+				}
+			}
+		}
+	}
+}
+
+// TestSequenceSharesOrbit checks that the bands of a perturbation render share one reference orbit
+func TestSequenceSharesOrbit(t *testing.T) {
+	req := DefaultRequest()
+	req.Numerics = config.PerturbationNumericsMode
+	req.Jobs = 4
+	req.ImageWidth = 40
+	req.ImageHeight = 30
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	facade := makeSequenceFacade(desc)
+	_, err = facade.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orbit := facade.factory.reference.Orbit
+	if orbit == nil {
+		t.Fatal("Expected reference orbit after render")
+	}
+	band := facade.factory.Build().(*perturbsequence.PerturbSequenceNumerics)
+	if band.Orbit != orbit {
+		t.Error("Expected band to share reference orbit")
+	}
+	if band.Series != facade.factory.reference.Series {
+		t.Error("Expected band to share series")
+	}
+}
+
+// TestRenderJitter checks that every numerics jitters each pixel the same way, whichever job
+// renders it, and that jittered samples are not those of the grid
+func TestRenderJitter(t *testing.T) {
+	modes := []config.NumericsMode{
+		config.NativeNumericsMode,
+		config.BigFloatNumericsMode,
+		config.PerturbationNumericsMode,
+		config.DoubleDoubleNumericsMode,
+		config.FixedPointNumericsMode,
+	}
+	for _, mode := range modes {
+		render := func(jobs uint16, sampling config.SamplingPattern) *image.NRGBA {
+			req := DefaultRequest()
+			req.Numerics = mode
+			req.Jobs = jobs
+			req.PixelSamples = 4
+			req.Sampling = sampling
+			req.ImageWidth = 30
+			req.ImageHeight = 20
+			req.IterateLimit = 200
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pic, err := Render(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return pic
+		}
+
+		jitter := render(1, config.JitterSampling)
+		if !bytes.Equal(jitter.Pix, render(3, config.JitterSampling).Pix) {
+			t.Error("Numerics", mode, "jittered differently with several jobs")
+		}
+		if bytes.Equal(jitter.Pix, render(1, config.GridSampling).Pix) {
+			t.Error("Numerics", mode, "jittered samples matched the grid")
+		}
+	}
+}
+
+// TestRenderPerturbationDeep checks perturbation numerics against big floats at a depth beyond
+// native and double-double precision.  The reference at the centre of the frame escapes before
+// some pixels, which must be rebased on to the start of its orbit.
+func TestRenderPerturbationDeep(t *testing.T) {
+	renderers := []config.RenderMode{
+		config.SequenceRenderMode,
+		config.RegionRenderMode,
+	}
+	for _, renderer := range renderers {
+		escapes := make([]*EscapeMap, 2)
+		for i, mode := range []config.NumericsMode{config.PerturbationNumericsMode, config.BigFloatNumericsMode} {
+			req := DefaultRequest()
+			req.Renderer = renderer
+			req.Numerics = mode
+			req.ImageWidth = 24
+			req.ImageHeight = 24
+			req.IterateLimit = 1000
+			req.Precision = 100
+			req.RealMin = "-1e-20"
+			req.RealMax = "3e-20"
+			req.ImagMin = "0.99999999999999999999"
+			req.ImagMax = "1.00000000000000000003"
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			escapes[i], err = RenderEscapeMap(context.Background(), desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		perturb := escapes[0].escapes.Values
+		exact := escapes[1].escapes.Values
+		escaped := map[uint32]bool{}
+		for i, p := range perturb {
+			e := exact[i]
+			if p.InSet != e.InSet || p.InvDiv != e.InvDiv {
+				t.Fatal("Renderer", renderer, "perturbation escape", p,
+					"differed from big escape", e, "at sample", i)
+			}
+			escaped[e.InvDiv] = true
+		}
+
+		if len(escaped) < 2 {
+			t.Error("Renderer", renderer, "expected a frame with detail but all points escaped alike")
+		}
+	}
+}
+
+func TestSequenceRenderTile(t *testing.T) {
+	testRenderTile(t, config.SequenceRenderMode)
+}
+
+func TestRegionRenderTile(t *testing.T) {
+	testRenderTile(t, config.RegionRenderMode)
+}
+
+// testRenderTile checks that a tile is rendered as the same part of the whole picture
+func testRenderTile(t *testing.T, renderer config.RenderMode) {
+	modes := []config.NumericsMode{
+		config.NativeNumericsMode,
+		config.BigFloatNumericsMode,
+		config.PerturbationNumericsMode,
+		config.DoubleDoubleNumericsMode,
+		config.FixedPointNumericsMode,
+	}
+	tile := config.ZoomBounds{Xmin: 37, Xmax: 101, Ymin: 13, Ymax: 70}
+	for _, mode := range modes {
+		pictures := make([]image.Image, 2)
+		for i, bounds := range []config.ZoomBounds{config.ZoomBounds{}, tile} {
+			req := DefaultRequest()
+			req.Renderer = renderer
+			req.Numerics = mode
+			req.Jobs = 2
+			req.Tile = bounds
+			req.ImageWidth = 120
+			req.ImageHeight = 90
+			req.IterateLimit = 200
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pictures[i], err = Render(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		bounds := pictures[1].Bounds()
+		if bounds != tile.Rect() {
+			t.Fatal("Numerics", mode, "rendered tile with bounds", bounds)
+		}
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				if pictures[0].At(x, y) != pictures[1].At(x, y) {
+					t.Fatal("Numerics", mode, "rendered tile differently at", x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderProgressive(t *testing.T) {
+	renderers := map[config.RenderMode]int{
+		config.SequenceRenderMode:   3,
+		config.RegionRenderMode:     1,
+		config.BuddhabrotRenderMode: 3,
+	}
+	for renderer, expectPasses := range renderers {
+		req := DefaultRequest()
+		req.Renderer = renderer
+		req.Numerics = config.NativeNumericsMode
+		req.Jobs = 3
+		req.ImageWidth = 50
+		req.ImageHeight = 40
+		req.IterateLimit = 500
+		req.RealMin = "-0.743647"
+		req.RealMax = "-0.743627"
+		req.ImagMin = "0.131817"
+		req.ImagMax = "0.131837"
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		whole, err := Render(desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		snapshots := []*image.NRGBA{}
+		progressive, err := RenderProgressive(context.Background(), desc, func(pic *image.NRGBA) {
+			snapshots = append(snapshots, pic)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(snapshots) != expectPasses {
+			t.Error("Renderer", renderer, "expected", expectPasses, "snapshots but received", len(snapshots))
+		}
+
+		for _, snapshot := range snapshots {
+			if snapshot == progressive || snapshot.Bounds() != whole.Bounds() {
+				t.Error("Renderer", renderer, "expected snapshot to be a copy of the picture")
+			}
+		}
+
+		if !bytes.Equal(whole.Pix, progressive.Pix) {
+			t.Error("Renderer", renderer, "rendered differently when progressive")
+		}
+	}
+}
+
+func TestRenderContextCancelled(t *testing.T) {
+	renderers := []config.RenderMode{
+		config.SequenceRenderMode,
+		config.RegionRenderMode,
+		config.BuddhabrotRenderMode,
+	}
+	for _, renderer := range renderers {
+		req := DefaultRequest()
+		req.Renderer = renderer
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		pic, err := RenderContext(ctx, desc)
+		if pic != nil || err != context.Canceled {
+			t.Error("Renderer", renderer, "expected cancellation but received", pic, err)
+		}
+	}
+}
+
+func TestRenderProgressiveCancelled(t *testing.T) {
+	req := DefaultRequest()
+	req.Renderer = config.SequenceRenderMode
+
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	passes := 0
+	pic, err := RenderProgressive(ctx, desc, func(*image.NRGBA) {
+		passes++
+		cancel()
+	})
+
+	if pic != nil || err != context.Canceled {
+		t.Error("Expected cancellation but received", pic, err)
+	}
+
+	if passes != 1 {
+		t.Error("Expected one pass before cancellation but there were", passes)
+	}
+}
+
+func TestRenderObserve(t *testing.T) {
+	renderers := []config.RenderMode{
+		config.SequenceRenderMode,
+		config.RegionRenderMode,
+	}
+	numerics := []config.NumericsMode{
+		config.NativeNumericsMode,
+		config.BigFloatNumericsMode,
+	}
+	for _, renderer := range renderers {
+		for _, num := range numerics {
+			req := DefaultRequest()
+			req.Renderer = renderer
+			req.Numerics = num
+			req.Jobs = 3
+			req.PixelSamples = 4
+			req.ImageWidth = 40
+			req.ImageHeight = 30
+			req.IterateLimit = 100
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := MakeRenderer(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var last Progress
+			r.Observe(func(p Progress) {
+				if p.Pixels < last.Pixels {
+					t.Error("Renderer", renderer, num, "counted backwards from", last, "to", p)
+				}
+				last = p
+			})
+
+			_, err = r.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if last.Phase != SequencePhase || last.Total == 0 || last.Pixels != last.Total || last.Regions != 0 {
+				t.Error("Renderer", renderer, num, "expected complete progress but received", last)
+			}
+		}
+	}
+}
+
+func TestEscapeMapRecolor(t *testing.T) {
+	renderers := []config.RenderMode{
+		config.SequenceRenderMode,
+		config.RegionRenderMode,
+	}
+	samples := []uint{1, 4}
+	for _, renderer := range renderers {
+		for _, pixelSamples := range samples {
+			req := DefaultRequest()
+			req.Renderer = renderer
+			req.Numerics = config.NativeNumericsMode
+			req.PixelSamples = pixelSamples
+			req.Jobs = 2
+			req.ImageWidth = 40
+			req.ImageHeight = 30
+			req.IterateLimit = 300
+			req.Smooth = true
+
+			desc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			em, err := RenderEscapeMap(context.Background(), desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			buff := &bytes.Buffer{}
+			err = WriteEscapeMap(buff, em)
+			if err != nil {
+				t.Fatal(err)
+			}
+			read, err := ReadEscapeMap(buff)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if read.Bounds() != em.Bounds() || read.Info.UserRequest != desc.UserRequest {
+				t.Error("Renderer", renderer, "escape map changed when read")
+			}
+
+			gray, err := Render(desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(gray.Pix, read.Recolor(nil).Pix) {
+				t.Error("Renderer", renderer, "samples", pixelSamples, "expected recolour as rendered")
+			}
+
+			req.PaletteCode = "pretty"
+			prettyDesc, err := Configure(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pretty, err := Render(prettyDesc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(pretty.Pix, read.Recolor(prettyDesc).Pix) {
+				t.Error("Renderer", renderer, "samples", pixelSamples, "expected recolour with pretty palette")
+			}
+		}
+	}
+}
+
+func TestReadEscapeMapInvalid(t *testing.T) {
+	_, err := ReadEscapeMap(bytes.NewBufferString("GODELBROT escape map"))
+	if err == nil {
+		t.Error("Expected error reading invalid escape map")
+	}
+
+	req := DefaultRequest()
+	req.ImageWidth = 4
+	req.ImageHeight = 3
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	em, err := RenderEscapeMap(context.Background(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buff := &bytes.Buffer{}
+	err = WriteEscapeMap(buff, em)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := buff.Bytes()
+
+	// The bounds and samples precede the escape values
+	boundsAt := len(valid) - (4 * 3 * escapeSize) - 20
+	headers := map[string]escapeBounds{
+		"overflowing":       {MinX: math.MinInt32, MinY: math.MinInt32, MaxX: math.MaxInt32, MaxY: math.MaxInt32, Samples: math.MaxUint32},
+		"too large":         {MaxX: 1 << 16, MaxY: 1 << 16, Samples: 1},
+		"longer than input": {MaxX: 4, MaxY: 3, Samples: 2},
+	}
+	for name, bounds := range headers {
+		header := &bytes.Buffer{}
+		err = binary.Write(header, binary.LittleEndian, bounds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		corrupt := append([]byte{}, valid[:boundsAt]...)
+		corrupt = append(corrupt, header.Bytes()...)
+		corrupt = append(corrupt, valid[boundsAt+header.Len():]...)
+
+		_, err = ReadEscapeMap(bytes.NewReader(corrupt))
+		if err == nil {
+			t.Error("Expected error reading escape map with", name, "bounds")
+		}
+	}
+
+	_, err = ReadEscapeMap(bytes.NewReader(valid[:len(valid)-1]))
+	if err == nil {
+		t.Error("Expected error reading truncated escape map")
+	}
+}
+
+func TestRenderHistogram(t *testing.T) {
+	renderers := []config.RenderMode{
+		config.SequenceRenderMode,
+		config.RegionRenderMode,
+	}
+	for _, renderer := range renderers {
+		req := DefaultRequest()
+		req.Renderer = renderer
+		req.Numerics = config.NativeNumericsMode
+		req.ImageWidth = 40
+		req.ImageHeight = 30
+		req.IterateLimit = 500
+		req.PaletteCode = "pretty"
+		req.Histogram = true
+
+		desc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		picture, err := Render(desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		progressive, err := RenderProgressive(context.Background(), desc, func(*image.NRGBA) {})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		em, err := RenderEscapeMap(context.Background(), desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Histogram = false
+		linearDesc, err := Configure(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		linear, err := Render(linearDesc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(picture.Pix, em.Recolor(nil).Pix) {
+			t.Error("Renderer", renderer, "expected escape map to recolour by histogram")
+		}
+
+		if !bytes.Equal(picture.Pix, progressive.Pix) {
+			t.Error("Renderer", renderer, "rendered histogram differently when progressive")
+		}
+
+		if bytes.Equal(picture.Pix, linear.Pix) {
+			t.Error("Renderer", renderer, "expected histogram to change colours")
+		}
+	}
+}
+
+func TestRenderPaletteOffset(t *testing.T) {
+	req := DefaultRequest()
+	req.Numerics = config.NativeNumericsMode
+	req.ImageWidth = 40
+	req.ImageHeight = 30
+	req.PaletteCode = "pretty"
+
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	em, err := RenderEscapeMap(context.Background(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := em.Recolor(nil)
+
+	req.PaletteOffset = 0.5
+	offsetDesc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	picture, err := Render(offsetDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(picture.Pix, plain.Pix) {
+		t.Error("Expected palette offset to change colours")
+	}
+	if !bytes.Equal(picture.Pix, em.Recolor(offsetDesc).Pix) {
+		t.Error("Expected escape map to recolour with palette offset")
+	}
+
+	// A whole cycle returns to the first colours
+	req.PaletteOffset = 1
+	cycleDesc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain.Pix, em.Recolor(cycleDesc).Pix) {
+		t.Error("Expected offset of one cycle to keep colours")
+	}
+
+	req.PaletteOffset = math.NaN()
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for NaN palette offset")
+	}
+}
@@ -2,6 +2,7 @@ package godelbrot
 
 import (
 	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"math"
 	"math/big"
 )
 
@@ -26,6 +27,33 @@ const MandelbrotMin complex128 = -2.01 - 1.11i
 // Maximum bounds of Mandelbrot set
 const MandelbrotMax complex128 = 0.59 + 1.13i
 
+// MultibrotBounds returns the minimum and maximum bounds of the Multibrot set for z^n + c.
+// The set lies within the disk of radius 2^(1/(n-1)).
+func MultibrotBounds(exponent uint) (complex128, complex128) {
+	if exponent <= 2 {
+		return MandelbrotMin, MandelbrotMax
+	}
+	// Round up to a short decimal, so the bounds do not demand high precision
+	radius := math.Pow(2, 1/float64(exponent-1))
+	radius = math.Ceil(radius*10) / 10
+	return complex(-radius, -radius), complex(radius, radius)
+}
+
+// Minimum bounds of Julia sets
+const JuliaMin complex128 = -2 - 1.5i
+
+// Maximum bounds of Julia sets
+const JuliaMax complex128 = 2 + 1.5i
+
+// JuliaBounds returns the minimum and maximum bounds of Julia sets for z^n + c.  When n > 2,
+// the Julia set of any point in the Multibrot set lies within the same disk as the Multibrot set.
+func JuliaBounds(exponent uint) (complex128, complex128) {
+	if exponent <= 2 {
+		return JuliaMin, JuliaMax
+	}
+	return MultibrotBounds(exponent)
+}
+
 // Named bignums
 var bigZero big.Float = bigbase.MakeBigFloat(0, DefaultHighPrec)
 var bigOne big.Float = bigbase.MakeBigFloat(1, DefaultHighPrec)
@@ -39,7 +67,9 @@ const DefaultHighPrec uint = 500
 // Default precision is for native arithmetic
 const DefaultPrecision uint = 53
 
-const DefaultIterations uint8 = 255
+const DefaultIterations uint32 = 255
+const DefaultExponent uint = 2
+const DefaultFormulaCode string = "mandelbrot"
 const DefaultDivergeLimit float64 = 4.0
 const DefaultImageWidth uint = 600
 const DefaultImageHeight uint = 600
@@ -54,3 +84,12 @@ const DefaultTinyImageArea uint = 40000
 
 // Default sample size for region glitch-correction
 const DefaultRegionSamples uint = 12
+
+// Default number of orbits plotted by the Buddhabrot renderer
+const DefaultBuddhaSamples uint = 1000000
+
+// Default number of terms in series approximation
+const DefaultSeriesTerms uint = 8
+
+// Default relative error tolerated by series approximation
+const DefaultSeriesTolerance float64 = 1e-6
@@ -6,8 +6,10 @@ import (
 )
 
 type nativeCoords struct {
-	userMin complex128
-	userMax complex128
+	userMin   complex128
+	userMax   complex128
+	juliaSeed complex128
+	formula   nativebase.Formula
 }
 
 var _ nativebase.NativeCoordProvider = (*nativeCoords)(nil)
@@ -16,9 +18,24 @@ func (coords *nativeCoords) NativeUserCoords() (complex128, complex128) {
 	return coords.userMin, coords.userMax
 }
 
+func (coords *nativeCoords) NativeJuliaSeed() complex128 {
+	return coords.juliaSeed
+}
+
+func (coords *nativeCoords) NativeFormula() nativebase.Formula {
+	return coords.formula
+}
+
 func makeNativeCoords(desc *Info) *nativeCoords {
 	coords := &nativeCoords{}
-	bigNums := []*big.Float{&desc.RealMin, &desc.ImagMin, &desc.RealMax, &desc.ImagMax}
+	bigNums := []*big.Float{
+		&desc.RealMin,
+		&desc.ImagMin,
+		&desc.RealMax,
+		&desc.ImagMax,
+		&desc.JuliaReal,
+		&desc.JuliaImag,
+	}
 	native := make([]float64, len(bigNums))
 
 	for i, heapNum := range bigNums {
@@ -27,6 +44,8 @@ func makeNativeCoords(desc *Info) *nativeCoords {
 
 	coords.userMin = complex(native[0], native[1])
 	coords.userMax = complex(native[2], native[3])
+	coords.juliaSeed = complex(native[4], native[5])
+	coords.formula = findFormula(desc).native
 	return coords
 }
 
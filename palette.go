@@ -0,0 +1,131 @@
+package godelbrot
+
+import (
+	"fmt"
+	"github.com/johnny-morrice/godelbrot/config"
+	"github.com/johnny-morrice/godelbrot/internal/base"
+	"github.com/johnny-morrice/godelbrot/internal/draw"
+	"image/color"
+	"log"
+	"sort"
+	"sync"
+)
+
+// EscapeValue describes how a point escaped, or stayed in, the set
+type EscapeValue = base.EscapeValue
+
+// Palette colours points by their escape values
+type Palette interface {
+	Color(point EscapeValue) color.NRGBA
+}
+
+// Interpolator is a Palette that can also colour the continuous escape value of a point, as
+// smooth and histogram colouring require.
+type Interpolator interface {
+	Palette
+	Interpolate(point EscapeValue) color.NRGBA
+}
+
+// PaletteFactory creates a palette for the request, or an error if the request does not suit
+// the palette.
+type PaletteFactory func(req *config.Request) (Palette, error)
+
+// Registered palettes, by code
+var palettes = map[string]PaletteFactory{}
+var palettesMutex sync.RWMutex
+
+// Codes of the palettes named by a PaletteKind
+var paletteKinds = map[string]PaletteKind{
+	"grayscale": Grayscale,
+	"redscale":  Redscale,
+	"pretty":    Pretty,
+	"gradient":  Gradient,
+}
+
+func init() {
+	RegisterPalette("grayscale", scalePalette(draw.NewGrayscalePalette))
+	RegisterPalette("redscale", scalePalette(draw.NewRedscalePalette))
+	RegisterPalette("pretty", scalePalette(draw.NewPrettyPalette))
+	RegisterPalette("gradient", gradientPalette)
+}
+
+// RegisterPalette makes the palettes created by factory available as the PaletteCode code.  It
+// panics if the code is already registered.
+func RegisterPalette(code string, factory PaletteFactory) {
+	palettesMutex.Lock()
+	defer palettesMutex.Unlock()
+
+	if factory == nil {
+		log.Panic("Nil PaletteFactory for palette code:", code)
+	}
+	if _, taken := palettes[code]; taken {
+		log.Panic("Palette code already registered:", code)
+	}
+	palettes[code] = factory
+}
+
+// PaletteCodes lists the codes of the registered palettes
+func PaletteCodes() []string {
+	palettesMutex.RLock()
+	defer palettesMutex.RUnlock()
+
+	codes := make([]string, 0, len(palettes))
+	for code := range palettes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func lookupPalette(code string) (PaletteFactory, bool) {
+	palettesMutex.RLock()
+	defer palettesMutex.RUnlock()
+	factory, ok := palettes[code]
+	return factory, ok
+}
+
+// makePalette creates the palette of the request, checking that it can interpolate when the
+// request needs it to.
+func makePalette(req *config.Request) (Palette, error) {
+	factory, ok := lookupPalette(req.PaletteCode)
+	if !ok {
+		return nil, fmt.Errorf("Invalid palette code: %v", req.PaletteCode)
+	}
+
+	palette, err := factory(req)
+	if err != nil {
+		return nil, err
+	}
+	if palette == nil {
+		return nil, fmt.Errorf("Palette %v is nil", req.PaletteCode)
+	}
+
+	if req.Smooth || req.Histogram {
+		if _, ok := palette.(Interpolator); !ok {
+			return nil, fmt.Errorf("Palette %v cannot interpolate", req.PaletteCode)
+		}
+	}
+
+	return palette, nil
+}
+
+// paletteCode returns the palette code of the Info.  Info written by older versions may give
+// only the PaletteKind.
+func paletteCode(desc *Info) string {
+	if code := desc.UserRequest.PaletteCode; code != "" {
+		return code
+	}
+	for code, kind := range paletteKinds {
+		if kind == desc.PaletteType {
+			return code
+		}
+	}
+	return ""
+}
+
+// scalePalette registers a built in palette that depends only on the iteration limit
+func scalePalette(factory draw.PaletteFactory) PaletteFactory {
+	return func(req *config.Request) (Palette, error) {
+		return factory(req.IterateLimit), nil
+	}
+}
@@ -0,0 +1,88 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/config"
+	"image/color"
+	"testing"
+)
+
+// Colours the set green and everything else blue
+type testPalette struct{}
+
+func (testPalette) Color(point EscapeValue) color.NRGBA {
+	if point.InSet {
+		return color.NRGBA{G: 255, A: 255}
+	}
+	return color.NRGBA{B: 255, A: 255}
+}
+
+func init() {
+	RegisterPalette("test", func(req *config.Request) (Palette, error) {
+		return testPalette{}, nil
+	})
+}
+
+func TestRegisterPalette(t *testing.T) {
+	req := DefaultRequest()
+	req.PaletteCode = "test"
+	req.ImageWidth = 20
+	req.ImageHeight = 10
+
+	desc, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	picture, err := Render(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	colors := map[color.NRGBA]bool{}
+	bounds := picture.Bounds()
+	for i := bounds.Min.X; i < bounds.Max.X; i++ {
+		for j := bounds.Min.Y; j < bounds.Max.Y; j++ {
+			colors[picture.NRGBAAt(i, j)] = true
+		}
+	}
+	green := color.NRGBA{G: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	if len(colors) != 2 || !colors[green] || !colors[blue] {
+		t.Error("Expected only the colours of the registered palette but received", colors)
+	}
+
+	// The palette does not interpolate
+	req.Smooth = true
+	if _, err := Configure(req); err == nil {
+		t.Error("Expected error for smooth colouring with a palette that cannot interpolate")
+	}
+}
+
+func TestRegisterPaletteTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when registering palette code twice")
+		}
+	}()
+	RegisterPalette("pretty", scalePalette(nil))
+}
+
+func TestPaletteCodes(t *testing.T) {
+	codes := map[string]bool{}
+	for _, code := range PaletteCodes() {
+		codes[code] = true
+	}
+	for code := range paletteKinds {
+		if !codes[code] {
+			t.Error("Expected built in palette", code, "to be registered")
+		}
+	}
+}
+
+func TestPaletteKindFallback(t *testing.T) {
+	desc := &Info{}
+	desc.PaletteType = Redscale
+	if code := paletteCode(desc); code != "redscale" {
+		t.Error("Expected redscale palette code for Info without code, but received", code)
+	}
+}
@@ -0,0 +1,55 @@
+package godelbrot
+
+import (
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/perturbregion"
+)
+
+type seriesProvider struct {
+	config perturbbase.SeriesConfig
+}
+
+var _ perturbbase.SeriesProvider = (*seriesProvider)(nil)
+
+func makeSeriesProvider(desc *Info) *seriesProvider {
+	req := desc.UserRequest
+	provider := &seriesProvider{}
+	// Zero terms disables series approximation
+	if req.SeriesApproximation {
+		provider.config.Terms = req.SeriesTerms
+		provider.config.Tolerance = req.SeriesTolerance
+	}
+	return provider
+}
+
+func (provider *seriesProvider) SeriesConfig() perturbbase.SeriesConfig {
+	return provider.config
+}
+
+type perturbBaseFacade struct {
+	*bigBaseFacade
+	*seriesProvider
+}
+
+var _ perturbbase.RenderApplication = (*perturbBaseFacade)(nil)
+
+func makePerturbBaseFacade(desc *Info, baseApp *baseFacade) *perturbBaseFacade {
+	app := &perturbBaseFacade{}
+	app.bigBaseFacade = makeBigBaseFacade(desc, baseApp)
+	app.seriesProvider = makeSeriesProvider(desc)
+	return app
+}
+
+type perturbRegionFacade struct {
+	*bigRegionFacade
+	*seriesProvider
+}
+
+var _ perturbregion.RenderApplication = (*perturbRegionFacade)(nil)
+
+func makePerturbRegionFacade(desc *Info, baseApp *baseFacade, region *regionProvider) *perturbRegionFacade {
+	facade := &perturbRegionFacade{}
+	facade.bigRegionFacade = makeBigRegionFacade(desc, baseApp, region)
+	facade.seriesProvider = makeSeriesProvider(desc)
+	return facade
+}
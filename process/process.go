@@ -2,10 +2,12 @@ package process
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	lib "github.com/johnny-morrice/godelbrot"
 	"github.com/johnny-morrice/pipeline"
 	"io"
+	"os"
 	"os/exec"
 )
 
@@ -18,15 +20,27 @@ func Config(stdout io.Writer, stderr io.Writer, args []string) error {
 // Render sends a new fractal image to the passed stdout pipe, corresponding to the Info
 // serialized in stdin.
 func Render(stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
-	render := renderbrot()
-	return runPipeCmd(render, stdin, stdout, stderr)
+	return RenderContext(context.Background(), stdin, stdout, stderr, nil)
+}
+
+// RenderContext is like Render, but interrupts the render when ctx is cancelled.  If progress
+// is not nil, it is called with the percentage of the render complete.
+func RenderContext(ctx context.Context, stdin io.Reader, stdout io.Writer, stderr io.Writer, progress func(float64)) error {
+	if progress == nil {
+		return runPipeCmd(renderbrot(ctx), stdin, stdout, stderr)
+	}
+
+	pw := &progressWriter{w: stderr, progress: progress}
+	err := runPipeCmd(renderbrot(ctx, "-progress"), stdin, stdout, pw)
+	pw.flush()
+	return err
 }
 
 // ConfigRender sends a new fractal image to the passed stdout pipe, corresponding to configbrot's
 // processing of the args slice.
 func ConfigRender(stdout io.Writer, stderr io.Writer, args []string) error {
 	config := configbrot(args)
-	render := renderbrot()
+	render := renderbrot(context.Background())
 
 	pl := pipeline.New(&bytes.Buffer{}, stdout, stderr)
 	pl.Chain(config, render)
@@ -42,6 +56,12 @@ func Zoom(stdin io.Reader, stdout io.Writer, stderr io.Writer, args []string) er
 // Zoom reads Info from stdin, and sends a fractal to stdout, returning the magnified Info,
 // serialized as an io.Reader.
 func ZoomRender(stdin io.Reader, stdout io.Writer, stderr io.Writer, args []string) (io.Reader, error) {
+	return ZoomRenderContext(context.Background(), stdin, stdout, stderr, args, nil)
+}
+
+// ZoomRenderContext is like ZoomRender, but interrupts the render when ctx is cancelled.  If
+// progress is not nil, it is called with the percentage of the render complete.
+func ZoomRenderContext(ctx context.Context, stdin io.Reader, stdout io.Writer, stderr io.Writer, args []string, progress func(float64)) (io.Reader, error) {
 	zoomBuff := &bytes.Buffer{}
 	zoomerr := Zoom(stdin, zoomBuff, stderr, args)
 	if zoomerr != nil {
@@ -51,7 +71,7 @@ func ZoomRender(stdin io.Reader, stdout io.Writer, stderr io.Writer, args []stri
 	outbuff := &bytes.Buffer{}
 	rendin := io.TeeReader(zoomBuff, outbuff)
 
-	err := Render(rendin, stdout, stderr)
+	err := RenderContext(ctx, rendin, stdout, stderr, progress)
 
 	return outbuff, err
 }
@@ -65,6 +85,7 @@ func ZoomArgs(target lib.ZoomTarget) []string {
 		"xmax",
 		"ymin",
 		"ymax",
+		"paletteshift",
 	}
 	actual := []string{
 		fmt.Sprint(target.Frames),
@@ -74,6 +95,7 @@ func ZoomArgs(target lib.ZoomTarget) []string {
 		fmt.Sprint(target.Xmax),
 		fmt.Sprint(target.Ymin),
 		fmt.Sprint(target.Ymax),
+		fmt.Sprint(target.PaletteShift),
 	}
 
 	opts := make([]string, len(formal))
@@ -92,8 +114,13 @@ func configbrot(args []string) *exec.Cmd {
 	return exec.Command("configbrot", args...)
 }
 
-func renderbrot() *exec.Cmd {
-	return exec.Command("renderbrot")
+// renderbrot is interrupted when ctx is cancelled, so that it may stop rendering
+func renderbrot(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "renderbrot", args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	return cmd
 }
 
 func runPipeCmd(cmd *exec.Cmd, stdin io.Reader, stdout, stderr io.Writer) error {
@@ -102,3 +129,46 @@ func runPipeCmd(cmd *exec.Cmd, stdin io.Reader, stdout, stderr io.Writer) error
 	cmd.Stderr = stderr
 	return cmd.Run()
 }
+
+// progressWriter passes the progress lines written by renderbrot -progress to a callback, and
+// any other lines on to w.
+type progressWriter struct {
+	w        io.Writer
+	progress func(float64)
+	buf      []byte
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.buf = append(pw.buf, p...)
+	for {
+		end := bytes.IndexByte(pw.buf, '\n')
+		if end < 0 {
+			return len(p), nil
+		}
+		line := pw.buf[:end+1]
+		pw.buf = pw.buf[end+1:]
+		if err := pw.line(line); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+func (pw *progressWriter) line(line []byte) error {
+	var phase string
+	var percent float64
+	_, err := fmt.Sscanf(string(line), "Progress: %s %f%%", &phase, &percent)
+	if err == nil {
+		pw.progress(percent)
+		return nil
+	}
+	_, err = pw.w.Write(line)
+	return err
+}
+
+// flush writes any unterminated final line
+func (pw *progressWriter) flush() {
+	if len(pw.buf) > 0 {
+		pw.line(pw.buf)
+		pw.buf = nil
+	}
+}
@@ -0,0 +1,74 @@
+package godelbrot
+
+import (
+	"fmt"
+	"github.com/johnny-morrice/godelbrot/internal/base"
+)
+
+// RenderPhase is a stage of the render
+type RenderPhase uint
+
+const (
+	// Regions are split until they are uniform or small
+	SubdividePhase = RenderPhase(base.SubdividePhase)
+	// Uniform regions are filled
+	UniformPhase = RenderPhase(base.UniformPhase)
+	// Pixels are computed one by one
+	SequencePhase = RenderPhase(base.SequencePhase)
+	// Orbits are sampled for the Buddhabrot, each counting as a pixel
+	SamplePhase = RenderPhase(base.SamplePhase)
+)
+
+func (phase RenderPhase) String() string {
+	switch phase {
+	case SubdividePhase:
+		return "subdivide"
+	case UniformPhase:
+		return "uniform"
+	case SequencePhase:
+		return "sequence"
+	case SamplePhase:
+		return "sample"
+	default:
+		return fmt.Sprintf("RenderPhase(%d)", uint(phase))
+	}
+}
+
+// Progress describes how far a render has got
+type Progress struct {
+	Phase RenderPhase
+	// Pixels computed or drawn so far, counting each supersample
+	Pixels int
+	// Pixels computed or drawn by the whole render.  This is not known during subdivision.
+	Total int
+	// Regions waiting to be subdivided or drawn
+	Regions int
+}
+
+// Percent returns the percentage of pixels complete
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return 100 * float64(p.Pixels) / float64(p.Total)
+}
+
+func (p Progress) String() string {
+	return fmt.Sprintf("%v %.1f%% (%v/%v pixels, %v regions)",
+		p.Phase, p.Percent(), p.Pixels, p.Total, p.Regions)
+}
+
+// tracker reports the progress of a render to observer, which may be nil.
+func tracker(observer func(Progress)) *base.Tracker {
+	if observer == nil {
+		return nil
+	}
+	return base.NewTracker(func(p base.Progress) {
+		observer(Progress{
+			Phase:   RenderPhase(p.Phase),
+			Pixels:  p.Pixels,
+			Total:   p.Total,
+			Regions: p.Regions,
+		})
+	})
+}
@@ -1,9 +1,14 @@
 package godelbrot
 
 import (
+	"context"
 	"github.com/johnny-morrice/godelbrot/config"
 	"github.com/johnny-morrice/godelbrot/internal/bigregion"
+	"github.com/johnny-morrice/godelbrot/internal/ddregion"
+	"github.com/johnny-morrice/godelbrot/internal/fixregion"
 	"github.com/johnny-morrice/godelbrot/internal/nativeregion"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/perturbregion"
 	"github.com/johnny-morrice/godelbrot/internal/region"
 	"image"
 	"log"
@@ -28,6 +33,7 @@ type regionFacade struct {
 	*regionProvider
 	*baseFacade
 	*drawFacade
+	report RenderReport
 }
 
 var _ region.RenderApplication = (*regionFacade)(nil)
@@ -42,10 +48,18 @@ func makeRegionFacade(desc *Info) *regionFacade {
 	}
 
 	provider := &regionProvider{}
-	provider.factory = &regionNumericsFactory{desc, baseApp, provider}
+	provider.factory = &regionNumericsFactory{
+		desc:     desc,
+		baseApp:  baseApp,
+		provider: provider,
+		report:   &facade.report,
+	}
 	provider.regionConfig = region.RegionConfig{
 		Samples:      req.RegionSamples,
 		CollapseSize: req.RegionCollapse,
+
+		InteriorDetail: req.Interior != config.FlatInterior,
+		Concurrent:     req.Jobs > 1,
 	}
 
 	facade.regionProvider = provider
@@ -57,14 +71,44 @@ func (facade *regionFacade) RegionNumericsFactory() region.RegionNumericsFactory
 }
 
 func (facade *regionFacade) Render() (*image.NRGBA, error) {
+	return facade.RenderContext(context.Background())
+}
+
+func (facade *regionFacade) RenderContext(ctx context.Context) (*image.NRGBA, error) {
+	return facade.colour(ctx, facade.render)
+}
+
+func (facade *regionFacade) RenderProgressive(ctx context.Context, progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	return facade.colour(ctx, func(ctx context.Context) (*image.NRGBA, error) {
+		facade.begin(ctx)
+		renderer := region.Make(facade)
+		picture, err := renderer.RenderProgressive(snapshots(progress))
+		return finish(ctx, picture, err)
+	})
+}
+
+func (facade *regionFacade) RenderEscapeMap(ctx context.Context) (*EscapeMap, error) {
+	return facade.renderEscapes(ctx, facade.render)
+}
+
+func (facade *regionFacade) render(ctx context.Context) (*image.NRGBA, error) {
+	facade.begin(ctx)
 	renderer := region.Make(facade)
-	return renderer.Render()
+	picture, err := renderer.Render()
+	return finish(ctx, picture, err)
+}
+
+func (facade *regionFacade) Report() RenderReport {
+	return facade.report
 }
 
 type regionNumericsFactory struct {
 	desc     *Info
 	baseApp  *baseFacade
 	provider *regionProvider
+	report   *RenderReport
+	// The reference orbit and series depend only on the frame, so every render shares them
+	reference perturbbase.Reference
 }
 
 func (factory *regionNumericsFactory) Build() region.RegionNumerics {
@@ -77,6 +121,22 @@ func (factory *regionNumericsFactory) Build() region.RegionNumerics {
 		app := makeBigRegionFacade(factory.desc, factory.baseApp, factory.provider)
 		bigApp := bigregion.Make(app)
 		return &bigApp
+	case config.PerturbationNumericsMode:
+		app := makePerturbRegionFacade(factory.desc, factory.baseApp, factory.provider)
+		fresh := factory.reference.Orbit == nil
+		perturbApp := perturbregion.MakeShared(app, &factory.reference)
+		if fresh {
+			factory.report.SkippedIterations = perturbApp.Series.Skip
+		}
+		return &perturbApp
+	case config.DoubleDoubleNumericsMode:
+		app := makeDDRegionFacade(factory.desc, factory.baseApp, factory.provider)
+		ddApp := ddregion.Make(app)
+		return &ddApp
+	case config.FixedPointNumericsMode:
+		app := makeFixedRegionFacade(factory.desc, factory.baseApp, factory.provider)
+		fixApp := fixregion.Make(app)
+		return &fixApp
 	default:
 		log.Panic("Invalid NumericsStrategy", factory.desc.NumericsStrategy)
 		return nil
@@ -0,0 +1,7 @@
+package godelbrot
+
+// RenderReport describes the work done by a Renderer
+type RenderReport struct {
+	// Iterations skipped for every point by series approximation
+	SkippedIterations uint32
+}
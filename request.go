@@ -19,12 +19,16 @@ type NativeInfo struct {
 	Precision        uint
 }
 
+// PaletteKind names a built in palette.  Palettes are found by UserRequest.PaletteCode, so
+// PaletteKind only matters for Info written by older versions, which may lack the code.
 type PaletteKind uint8
 
 const (
 	Grayscale = PaletteKind(iota)
 	Redscale
 	Pretty
+	// Colours blended between the control points of UserRequest.Gradient
+	Gradient
 )
 
 type BigInfo struct {
@@ -32,13 +36,18 @@ type BigInfo struct {
 	RealMax big.Float
 	ImagMin big.Float
 	ImagMax big.Float
+	// Julia set constant
+	JuliaReal big.Float
+	JuliaImag big.Float
 }
 
 type SerialBigInfo struct {
-	RealMin string
-	RealMax string
-	ImagMin string
-	ImagMax string
+	RealMin   string
+	RealMax   string
+	ImagMin   string
+	ImagMax   string
+	JuliaReal string
+	JuliaImag string
 }
 
 // Info completely describes the render process
@@ -53,9 +62,23 @@ func (info *Info) bignums() []*big.Float {
 		&info.RealMax,
 		&info.ImagMin,
 		&info.ImagMax,
+		&info.JuliaReal,
+		&info.JuliaImag,
 	}
 }
 
+// clone returns a copy of info that shares none of its bignums.
+func (info *Info) clone() *Info {
+	other := new(Info)
+	*other = *info
+	mine := other.bignums()
+	for i, x := range info.bignums() {
+		*mine[i] = big.Float{}
+		mine[i].Copy(x)
+	}
+	return other
+}
+
 // IsAccurate returns True if the bignums used internally by info are all accurate.
 func (info *Info) IsAccurate() bool {
 	for _, x := range info.bignums() {
@@ -87,6 +110,8 @@ func (info *Info) GenRequest() config.Request {
 	req.RealMax = emitBig(&info.RealMax)
 	req.ImagMin = emitBig(&info.ImagMin)
 	req.ImagMax = emitBig(&info.ImagMax)
+	req.JuliaReal = emitBig(&info.JuliaReal)
+	req.JuliaImag = emitBig(&info.JuliaImag)
 
 	return req
 }
@@ -104,6 +129,8 @@ func Friendly(desc *Info) *UserInfo {
 	userDesc.RealMax = emitBig(&desc.RealMax)
 	userDesc.ImagMin = emitBig(&desc.ImagMin)
 	userDesc.ImagMax = emitBig(&desc.ImagMax)
+	userDesc.JuliaReal = emitBig(&desc.JuliaReal)
+	userDesc.JuliaImag = emitBig(&desc.JuliaImag)
 	return userDesc
 }
 
@@ -116,11 +143,20 @@ func Unfriendly(userDesc *UserInfo) (*Info, error) {
 		userDesc.RealMax,
 		userDesc.ImagMin,
 		userDesc.ImagMax,
+		userDesc.JuliaReal,
+		userDesc.JuliaImag,
 	}
 	bnds := make([]*big.Float, len(ubnds))
 
 	for i, u := range ubnds {
-		b, err := parseBig(u)
+		var b *big.Float
+		var err error
+		// Julia constant is absent from Mandelbrot Info produced by older versions
+		if i >= 4 {
+			b, err = parseOptionalBig(u)
+		} else {
+			b, err = parseBig(u)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("Error parsing bound: %v", err)
 		}
@@ -132,6 +168,8 @@ func Unfriendly(userDesc *UserInfo) (*Info, error) {
 	desc.RealMax = *bnds[1]
 	desc.ImagMin = *bnds[2]
 	desc.ImagMax = *bnds[3]
+	desc.JuliaReal = *bnds[4]
+	desc.JuliaImag = *bnds[5]
 
 	return desc, nil
 }
@@ -219,20 +257,31 @@ func ReadInfoStream(r io.Reader) <-chan InfoPkt {
 }
 
 func DefaultRequest() *config.Request {
+	return DefaultMultibrotRequest(DefaultExponent)
+}
+
+// DefaultMultibrotRequest is the default request for z^n + c, framing the whole set.
+func DefaultMultibrotRequest(exponent uint) *config.Request {
+	min, max := MultibrotBounds(exponent)
 	return &config.Request{
-		IterateLimit:   DefaultIterations,
-		DivergeLimit:   DefaultDivergeLimit,
-		RegionCollapse: DefaultCollapse,
-		RegionSamples:  DefaultRegionSamples,
-		RealMin:        float2str(real(MandelbrotMin)),
-		ImagMin:        float2str(imag(MandelbrotMin)),
-		RealMax:        float2str(real(MandelbrotMax)),
-		ImagMax:        float2str(imag(MandelbrotMax)),
-		ImageHeight:    DefaultImageHeight,
-		ImageWidth:     DefaultImageWidth,
-		FixAspect:      config.Shrink,
-		PaletteCode:    "grayscale",
-		Jobs:           1,
+		IterateLimit:    DefaultIterations,
+		DivergeLimit:    DefaultDivergeLimit,
+		RegionCollapse:  DefaultCollapse,
+		RegionSamples:   DefaultRegionSamples,
+		SeriesTerms:     DefaultSeriesTerms,
+		SeriesTolerance: DefaultSeriesTolerance,
+		Exponent:        exponent,
+		RealMin:         float2str(real(min)),
+		ImagMin:         float2str(imag(min)),
+		RealMax:         float2str(real(max)),
+		ImagMax:         float2str(imag(max)),
+		ImageHeight:     DefaultImageHeight,
+		ImageWidth:      DefaultImageWidth,
+		FixAspect:       config.Shrink,
+		PaletteCode:     "grayscale",
+		FormulaCode:     DefaultFormulaCode,
+		BuddhaSamples:   DefaultBuddhaSamples,
+		Jobs:            1,
 	}
 }
 
@@ -0,0 +1,109 @@
+package godelbrot
+
+import (
+	"bytes"
+	"github.com/johnny-morrice/godelbrot/config"
+	"testing"
+)
+
+func TestReadInfoDeepIterateLimit(t *testing.T) {
+	const deep uint32 = 100000
+	req := DefaultRequest()
+	req.IterateLimit = deep
+
+	info, conferr := Configure(req)
+	if conferr != nil {
+		t.Fatal(conferr)
+	}
+
+	buff := &bytes.Buffer{}
+	writeerr := WriteInfo(buff, info)
+	if writeerr != nil {
+		t.Fatal(writeerr)
+	}
+
+	actual, readerr := ReadInfo(buff)
+	if readerr != nil {
+		t.Fatal(readerr)
+	}
+
+	if actual.UserRequest.IterateLimit != deep {
+		t.Error("Expected IterateLimit", deep,
+			"but received", actual.UserRequest.IterateLimit)
+	}
+}
+
+func TestReadInfoSmallIterateLimit(t *testing.T) {
+	// Info written when IterateLimit was a uint8
+	legacy := `{
+    "UserRequest": {
+        "IterateLimit": 255,
+        "DivergeLimit": 4,
+        "RealMin": "-2.01",
+        "RealMax": "0.59",
+        "ImagMin": "-1.11",
+        "ImagMax": "1.13",
+        "ImageWidth": 600,
+        "ImageHeight": 600,
+        "PaletteCode": "grayscale",
+        "FixAspect": 1,
+        "Renderer": 0,
+        "Jobs": 1,
+        "RegionCollapse": 4,
+        "Numerics": 0,
+        "RegionSamples": 12,
+        "Precision": 0
+    },
+    "RenderStrategy": 1,
+    "NumericsStrategy": 1,
+    "PaletteType": 0,
+    "Precision": 53,
+    "RealMin": "-2.01e+00",
+    "RealMax": "5.9e-01",
+    "ImagMin": "-1.11e+00",
+    "ImagMax": "1.13e+00"
+}`
+
+	info, err := ReadInfo(bytes.NewBufferString(legacy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.UserRequest.IterateLimit != 255 {
+		t.Error("Expected IterateLimit 255 but received", info.UserRequest.IterateLimit)
+	}
+}
+
+func TestReadInfoJulia(t *testing.T) {
+	req := DefaultRequest()
+	req.Fractal = config.JuliaFractal
+	req.JuliaReal = "-0.8"
+	req.JuliaImag = "0.156"
+
+	info, conferr := Configure(req)
+	if conferr != nil {
+		t.Fatal(conferr)
+	}
+
+	buff := &bytes.Buffer{}
+	writeerr := WriteInfo(buff, info)
+	if writeerr != nil {
+		t.Fatal(writeerr)
+	}
+
+	actual, readerr := ReadInfo(buff)
+	if readerr != nil {
+		t.Fatal(readerr)
+	}
+
+	if actual.UserRequest.Fractal != config.JuliaFractal {
+		t.Error("Expected Julia fractal but received", actual.UserRequest.Fractal)
+	}
+
+	okay := actual.JuliaReal.Cmp(&info.JuliaReal) == 0
+	okay = okay && actual.JuliaImag.Cmp(&info.JuliaImag) == 0
+	if !okay {
+		t.Error("Expected Julia seed", &info.JuliaReal, &info.JuliaImag,
+			"but received", &actual.JuliaReal, &actual.JuliaImag)
+	}
+}
@@ -18,6 +18,7 @@ type RQGetResp struct {
 	CreateTime   int64
 	CompleteTime int64
 	State        string
+	Percent      float64
 	Error        string
 	NextReq      config.Request
 	ImageURL     string
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/gob"
@@ -22,6 +23,7 @@ const (
 	__WAIT = rqstate(iota)
 	__DONE
 	__ERROR
+	__CANCEL
 )
 
 type rqitem struct {
@@ -30,8 +32,10 @@ type rqitem struct {
 	pkt          renderpacket
 	code         hashcode
 	state        rqstate
+	percent      float64
 	err          string
 	nextinfo     lib.Info
+	cancelfunc   context.CancelFunc
 	mutex        sync.RWMutex
 }
 
@@ -99,10 +103,18 @@ func (rqi *rqitem) done(nextinfo *lib.Info) {
 	writeM(rqi.mutex, func() {
 		rqi.nextinfo = *nextinfo
 		rqi.state = __DONE
+		rqi.percent = 100
 	})
 	rqi.logcomplete()
 }
 
+// progress records the percentage of the render complete
+func (rqi *rqitem) progress(percent float64) {
+	rqi.mutex.Lock()
+	rqi.percent = percent
+	rqi.mutex.Unlock()
+}
+
 func (rqi *rqitem) fail(msg string) {
 	writeM(rqi.mutex, func() {
 		rqi.err = msg
@@ -111,6 +123,28 @@ func (rqi *rqitem) fail(msg string) {
 	rqi.logcomplete()
 }
 
+// cancel stops the render, if it is not yet complete
+func (rqi *rqitem) cancel() {
+	rqi.mutex.RLock()
+	defer rqi.mutex.RUnlock()
+	if rqi.state == __WAIT && rqi.cancelfunc != nil {
+		rqi.cancelfunc()
+	}
+}
+
+func (rqi *rqitem) cancelled() {
+	rqi.mutex.Lock()
+	rqi.state = __CANCEL
+	rqi.mutex.Unlock()
+	rqi.logcomplete()
+}
+
+func (rqi *rqitem) isCancelled() bool {
+	rqi.mutex.RLock()
+	defer rqi.mutex.RUnlock()
+	return rqi.state == __CANCEL
+}
+
 func (rqi *rqitem) logcomplete() {
 	var state rqstate
 	var elapsed time.Duration
@@ -130,6 +164,8 @@ func (rqi *rqitem) logcomplete() {
 		log.Printf("rqitem %v rendered OK after %v", code, elapsed)
 	case __ERROR:
 		log.Printf("rqitem %v error after %v: %v", code, elapsed, err)
+	case __CANCEL:
+		log.Printf("rqitem %v cancelled after %v", code, elapsed)
 	default:
 		panic(fmt.Sprintf("rqitem %v completed after %v with bad state (%v): %v",
 			code, elapsed, state, pkt))
@@ -171,22 +207,36 @@ func (rq *renderqueue) enqueue(pkt *renderpacket) hashcode {
 	code := rqi.code
 	log.Printf("Queing packet %v", code)
 
-	_, present := rq.ca.get(code)
-	if present {
+	// Cancelled renders are begun again
+	any, present := rq.ca.get(code)
+	if present && !any.(*rqitem).isCancelled() {
 		debugf("Deduplicated packet %v", code)
 		return code
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	rqi.cancelfunc = cancel
+
 	debugf("Storing packet %v", code)
 	rq.ca.put(rqi)
 	go func() {
-		rq.sysdraw(rqi, pkt)
+		defer cancel()
+		rq.sysdraw(ctx, rqi, pkt)
 	}()
 
 	return code
 }
 
-func (rq *renderqueue) sysdraw(rqi *rqitem, pkt *renderpacket) {
+// cancel stops the render of the item with the code, returning false if there is no such item
+func (rq *renderqueue) cancel(code hashcode) bool {
+	any, present := rq.ca.get(code)
+	if present {
+		any.(*rqitem).cancel()
+	}
+	return present
+}
+
+func (rq *renderqueue) sysdraw(ctx context.Context, rqi *rqitem, pkt *renderpacket) {
 	code := hashcode("")
 	if __DEBUG {
 		code = rqi.hash()
@@ -204,12 +254,17 @@ func (rq *renderqueue) sysdraw(rqi *rqitem, pkt *renderpacket) {
 			berr, rqi.hash(), rqi.packet())
 		return
 	}
-	renderErr := rq.rs.render(buffs, zoomArgs)
+	renderErr := rq.rs.render(ctx, buffs, zoomArgs, rqi.progress)
 	debugf("Rendered packet %v", code)
 
 	// Copy any stderr messages
 	buffs.logReport()
 
+	if ctx.Err() != nil {
+		rqi.cancelled()
+		return
+	}
+
 	if renderErr != nil {
 		rqi.fail("failed render")
 		log.Printf("Render error: %v, for packet %v (%v)",
@@ -3,6 +3,7 @@ package rest
 import (
 	"bufio"
 	"bytes"
+	"context"
 	lib "github.com/johnny-morrice/godelbrot"
 	"github.com/johnny-morrice/godelbrot/process"
 	"io"
@@ -44,25 +45,31 @@ func makeRenderservice(concurrent uint) renderservice {
 	return rs
 }
 
-// render a fractal into the renderbuffers
-func (rs renderservice) render(rbuf *renderbuffers, zoomArgs []string) error {
+// render a fractal into the renderbuffers, stopping when ctx is cancelled, and calling progress
+// with the percentage complete
+func (rs renderservice) render(ctx context.Context, rbuf *renderbuffers, zoomArgs []string, progress func(float64)) error {
 	rs.s.acquire(1)
-	var err error
+	defer rs.s.release(1)
+
+	// The render may have been cancelled while it waited
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
+	var err error
 	if zoomArgs == nil || len(zoomArgs) == 0 {
 		debugf("Render in progress")
 		tee := io.TeeReader(&rbuf.info, &rbuf.nextinfo)
-		err = process.Render(tee, &rbuf.png, &rbuf.report)
+		err = process.RenderContext(ctx, tee, &rbuf.png, &rbuf.report, progress)
 		debugf("Render done")
 	} else {
 		debugf("ZoomRender in progress: %v", strings.Join(zoomArgs, " "))
-		next, zerr := process.ZoomRender(&rbuf.info, &rbuf.png, &rbuf.report, zoomArgs)
+		next, zerr := process.ZoomRenderContext(ctx, &rbuf.info, &rbuf.png, &rbuf.report, zoomArgs, progress)
 		err = zerr
 		if err == nil {
 			_, err = io.Copy(&rbuf.nextinfo, next)
 		}
 		debugf("ZoomRender done")
 	}
-	rs.s.release(1)
 	return err
 }
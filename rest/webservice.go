@@ -81,6 +81,7 @@ func MakeWebservice(baseinfo *lib.Info, concurrent uint, prefix string) http.Han
 	r := mux.NewRouter()
 	r.HandleFunc("/renderqueue/", ws.enterRQHandler).Methods("POST")
 	r.HandleFunc("/renderqueue/{rqcode}/", ws.getRQHandler).Methods("GET")
+	r.HandleFunc("/renderqueue/{rqcode}/", ws.cancelRQHandler).Methods("DELETE")
 	r.HandleFunc("/image/{rqcode}/", ws.getImageHandler).Methods("GET")
 
 	nc := nocache{}
@@ -113,6 +114,24 @@ func (ws *webservice) getRQHandler(w http.ResponseWriter, req *http.Request) {
 	withSession(w, req, ws.getRQ)
 }
 
+func (ws *webservice) cancelRQHandler(w http.ResponseWriter, req *http.Request) {
+	withSession(w, req, ws.cancelRQ)
+}
+
+func (ws *webservice) cancelRQ(s session) error {
+	input := s.getMuxVar("rqcode")
+	rqcode := hashcode(input)
+
+	if !ws.rq.cancel(rqcode) {
+		err := s.httpError(fmt.Sprintf("Invalid code: %v", rqcode), 400)
+		return err
+	}
+
+	log.Printf("Cancelled render of %v", rqcode)
+	s.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (ws *webservice) getRQ(s session) error {
 	input := s.getMuxVar("rqcode")
 	rqcode := hashcode(input)
@@ -134,15 +153,17 @@ func (ws *webservice) getRQ(s session) error {
 	var completetime int64
 	var rqerr string
 	var state rqstate
+	var percent float64
 	var nextreq config.Request
 	var code hashcode
 	readM(rqi.mutex, func() {
 		resp.CreateTime = rqi.createtime.Unix()
-		if rqi.state == __DONE || rqi.state == __ERROR {
+		if rqi.state != __WAIT {
 			completetime = rqi.completetime.Unix()
 		}
 		rqerr = rqi.err
 		state = rqi.state
+		percent = rqi.percent
 		nextreq = rqi.nextinfo.UserRequest
 		code = rqi.code
 	})
@@ -150,6 +171,7 @@ func (ws *webservice) getRQ(s session) error {
 	log.Printf("Sending next user request for %v: %v", code, nextreq)
 
 	resp.ThisUrl = ws.prefixed("renderqueue/%v/", code)
+	resp.Percent = percent
 
 	switch state {
 	case __DONE:
@@ -161,6 +183,9 @@ func (ws *webservice) getRQ(s session) error {
 		resp.State = "error"
 		resp.CompleteTime = completetime
 		resp.Error = rqerr
+	case __CANCEL:
+		resp.State = "cancelled"
+		resp.CompleteTime = completetime
 	case __WAIT:
 		resp.State = "wait"
 	default:
@@ -295,6 +320,32 @@ func (ws *webservice) mergeInfo(renreq *protocol.RenderRequest) (*lib.Info, erro
 		noplane = noplane || b == ""
 	}
 
+	// The whole Multibrot set is framed when the exponent changes.  Zero keeps the base exponent.
+	if exp := renreq.Req.Exponent; exp != 0 && exp != exponent(req.Exponent) {
+		req.Exponent = exp
+		if noplane {
+			min, max := lib.MultibrotBounds(req.Exponent)
+			req.RealMin = format(real(min))
+			req.ImagMin = format(imag(min))
+			req.RealMax = format(real(max))
+			req.ImagMax = format(imag(max))
+		}
+	}
+
+	// Julia sets are explored from a point chosen on the Mandelbrot set
+	req.Fractal = renreq.Req.Fractal
+	if req.Fractal == config.JuliaFractal {
+		req.JuliaReal = renreq.Req.JuliaReal
+		req.JuliaImag = renreq.Req.JuliaImag
+		if noplane {
+			min, max := lib.JuliaBounds(req.Exponent)
+			req.RealMin = format(real(min))
+			req.ImagMin = format(imag(min))
+			req.RealMax = format(real(max))
+			req.ImagMax = format(imag(max))
+		}
+	}
+
 	if !noplane {
 		req.RealMin = renreq.Req.RealMin
 		req.RealMax = renreq.Req.RealMax
@@ -307,6 +358,14 @@ func (ws *webservice) mergeInfo(renreq *protocol.RenderRequest) (*lib.Info, erro
 	return lib.Configure(&req)
 }
 
+// exponent returns the power of z, where zero means the default
+func exponent(n uint) uint {
+	if n == 0 {
+		return lib.DefaultExponent
+	}
+	return n
+}
+
 func format(f float64) string {
 	return strconv.FormatFloat(f, 'e', -1, 64)
 }
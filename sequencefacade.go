@@ -1,9 +1,14 @@
 package godelbrot
 
 import (
+	"context"
 	"github.com/johnny-morrice/godelbrot/config"
 	"github.com/johnny-morrice/godelbrot/internal/bigsequence"
+	"github.com/johnny-morrice/godelbrot/internal/ddsequence"
+	"github.com/johnny-morrice/godelbrot/internal/fixsequence"
 	"github.com/johnny-morrice/godelbrot/internal/nativesequence"
+	"github.com/johnny-morrice/godelbrot/internal/perturbbase"
+	"github.com/johnny-morrice/godelbrot/internal/perturbsequence"
 	"github.com/johnny-morrice/godelbrot/internal/sequence"
 	"image"
 	"log"
@@ -13,6 +18,7 @@ type sequenceFacade struct {
 	*baseFacade
 	*drawFacade
 	factory *sequenceNumericsFactory
+	report  RenderReport
 }
 
 // sequenceFacade implements a couple of interfaces
@@ -25,7 +31,11 @@ func makeSequenceFacade(info *Info) *sequenceFacade {
 		baseFacade: baseApp,
 		drawFacade: makeDrawFacade(info),
 	}
-	facade.factory = &sequenceNumericsFactory{info, baseApp}
+	facade.factory = &sequenceNumericsFactory{
+		desc:    info,
+		baseApp: baseApp,
+		report:  &facade.report,
+	}
 	return facade
 }
 
@@ -34,13 +44,43 @@ func (facade *sequenceFacade) SequenceNumericsFactory() sequence.SequenceNumeric
 }
 
 func (facade *sequenceFacade) Render() (*image.NRGBA, error) {
+	return facade.RenderContext(context.Background())
+}
+
+func (facade *sequenceFacade) RenderContext(ctx context.Context) (*image.NRGBA, error) {
+	return facade.colour(ctx, facade.render)
+}
+
+func (facade *sequenceFacade) RenderProgressive(ctx context.Context, progress func(*image.NRGBA)) (*image.NRGBA, error) {
+	return facade.colour(ctx, func(ctx context.Context) (*image.NRGBA, error) {
+		facade.begin(ctx)
+		renderer := sequence.Make(facade)
+		picture, err := renderer.RenderProgressive(snapshots(progress))
+		return finish(ctx, picture, err)
+	})
+}
+
+func (facade *sequenceFacade) RenderEscapeMap(ctx context.Context) (*EscapeMap, error) {
+	return facade.renderEscapes(ctx, facade.render)
+}
+
+func (facade *sequenceFacade) render(ctx context.Context) (*image.NRGBA, error) {
+	facade.begin(ctx)
 	renderer := sequence.Make(facade)
-	return renderer.Render()
+	picture, err := renderer.Render()
+	return finish(ctx, picture, err)
+}
+
+func (facade *sequenceFacade) Report() RenderReport {
+	return facade.report
 }
 
 type sequenceNumericsFactory struct {
 	desc    *Info
 	baseApp *baseFacade
+	report  *RenderReport
+	// The reference orbit and series depend only on the frame, so all bands share them
+	reference perturbbase.Reference
 }
 
 func (factory *sequenceNumericsFactory) Build() sequence.SequenceNumerics {
@@ -53,6 +93,22 @@ func (factory *sequenceNumericsFactory) Build() sequence.SequenceNumerics {
 		specialBase := makeBigBaseFacade(factory.desc, factory.baseApp)
 		bigApp := bigsequence.Make(specialBase)
 		return &bigApp
+	case config.PerturbationNumericsMode:
+		specialBase := makePerturbBaseFacade(factory.desc, factory.baseApp)
+		fresh := factory.reference.Orbit == nil
+		perturbApp := perturbsequence.MakeShared(specialBase, &factory.reference)
+		if fresh {
+			factory.report.SkippedIterations = perturbApp.Series.Skip
+		}
+		return &perturbApp
+	case config.DoubleDoubleNumericsMode:
+		specialBase := makeDDBaseFacade(factory.desc, factory.baseApp)
+		ddApp := ddsequence.Make(specialBase)
+		return &ddApp
+	case config.FixedPointNumericsMode:
+		specialBase := makeFixedBaseFacade(factory.desc, factory.baseApp)
+		fixApp := fixsequence.Make(specialBase)
+		return &fixApp
 	default:
 		log.Panic("Invalid NumericsStrategy", factory.desc.NumericsStrategy)
 		return nil
@@ -26,6 +26,12 @@ func main() {
 		info, ierr := lib.ReadInfo(os.Stdin)
 		fatalguard(ierr)
 		args.req = info.GenRequest()
+
+		if args.julia {
+			req, jerr := lib.JuliaAt(info, int(args.juliaX), int(args.juliaY))
+			fatalguard(jerr)
+			args.req = *req
+		}
 	}
 
 	shcl := newShellClient(args)
@@ -49,6 +55,10 @@ func main() {
 		}
 	})
 
+	if shcl.zoom && args.julia {
+		fatalguard(fmt.Errorf("Cannot zoom and choose a Julia set at once"))
+	}
+
 	// Ugly
 	var r io.Reader
 	if args.cycle {
@@ -157,8 +167,17 @@ func readArgs() params {
 	flag.UintVar(&args.zoombox.Xmax, "xmax", 0, "xmax pixel bound")
 	flag.UintVar(&args.zoombox.Ymin, "ymin", 0, "ymin pixel bound")
 	flag.UintVar(&args.zoombox.Ymax, "ymax", 0, "ymax pixel bound")
+	flag.UintVar(&args.juliaX, "juliax", 0, "x pixel of the point for a Julia set")
+	flag.UintVar(&args.juliaY, "juliay", 0, "y pixel of the point for a Julia set")
 	flag.Parse()
 
+	// Julia at this point, rather than zooming
+	flag.Visit(func(fl *flag.Flag) {
+		if fl.Name == "juliax" || fl.Name == "juliay" {
+			args.julia = true
+		}
+	})
+
 	// Cycle is default on only if no other operations provided.
 	operation := map[string]bool{
 		"getrq":   true,
@@ -190,6 +209,10 @@ type params struct {
 	req config.Request
 
 	zoombox config.ZoomBounds
+
+	julia  bool
+	juliaX uint
+	juliaY uint
 }
 
 func jsonr(any interface{}) (io.Reader, error) {
@@ -4,17 +4,26 @@ import (
 	"flag"
 	"github.com/johnny-morrice/godelbrot"
 	"image/png"
+	"io"
 	"log"
 	"os"
 )
 
 type commandLine struct {
-	config string
+	config    string
+	escapes   bool
+	frames    uint
+	offsetmin float64
+	offsetmax float64
 }
 
 func parseCommand() *commandLine {
 	args := &commandLine{}
 	flag.StringVar(&args.config, "config", "(none)", "Path to config file")
+	flag.BoolVar(&args.escapes, "escapes", false, "Read an escape map from renderbrot -escapes rather than a grayscale PNG")
+	flag.UintVar(&args.frames, "frames", 1, "Number of frames to colour from the escape map, written one after another")
+	flag.Float64Var(&args.offsetmin, "offsetmin", 0, "Palette offset of the first frame")
+	flag.Float64Var(&args.offsetmax, "offsetmax", 1, "Palette offset after the last frame, so a range of 1 cycles smoothly")
 	flag.Parse()
 	return args
 }
@@ -22,7 +31,7 @@ func parseCommand() *commandLine {
 func readInfo(args *commandLine) (*godelbrot.Info, error) {
 	if args.config == "(none)" {
 		desc := &godelbrot.Info{}
-		desc.PaletteType = godelbrot.Pretty
+		desc.UserRequest.PaletteCode = "pretty"
 		return desc, nil
 	} else {
 		f, err := os.Open(args.config)
@@ -34,11 +43,72 @@ func readInfo(args *commandLine) (*godelbrot.Info, error) {
 	}
 }
 
+// recolorEscapes colours an escape map exactly, once for each frame, offsetting the palette of
+// each frame in turn.  Without a config, the palette of the render is kept.
+func recolorEscapes(args *commandLine, output io.Writer) error {
+	em, err := godelbrot.ReadEscapeMap(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	var style *godelbrot.Info
+	if args.config != "(none)" {
+		style, err = readInfo(args)
+		if err != nil {
+			return err
+		}
+	}
+
+	if args.frames <= 1 && !offsetGiven() {
+		return png.Encode(output, em.Recolor(style))
+	}
+
+	if style == nil {
+		info := em.Info
+		style = &info
+	}
+	frames := args.frames
+	if frames == 0 {
+		frames = 1
+	}
+	for i := uint(0); i < frames; i++ {
+		degree := float64(i) / float64(frames)
+		style.UserRequest.PaletteOffset = args.offsetmin + (degree * (args.offsetmax - args.offsetmin))
+		err = png.Encode(output, em.Recolor(style))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// offsetGiven returns true when a palette offset was passed on the command line
+func offsetGiven() bool {
+	given := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "offsetmin" || f.Name == "offsetmax" {
+			given = true
+		}
+	})
+	return given
+}
+
 func main() {
 	input := os.Stdin
 	output := os.Stdout
 
 	args := parseCommand()
+
+	if args.escapes {
+		escErr := recolorEscapes(args, output)
+
+		if escErr != nil {
+			log.Fatal("Error recolouring escape map: ", escErr)
+		}
+		return
+	}
+
 	desc, argErr := readInfo(args)
 
 	if argErr != nil {
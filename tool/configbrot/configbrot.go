@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 func main() {
@@ -51,6 +52,30 @@ type commandLine struct {
 	precision      uint
 	reconfigure    bool
 	palette        string
+	gradient       string
+	paletteOffset  float64
+	paletteCycle   uint
+	smooth         bool
+	histogram      bool
+	series         bool
+	seriesTerms    uint
+	seriesTol      float64
+	fractal        string
+	juliaReal      string
+	juliaImag      string
+	exponent       uint
+	formula        string
+	cardioid       bool
+	periodicity    bool
+	shading        string
+	interior       string
+	buddhaSamples  uint
+	redLimit       uint
+	greenLimit     uint
+	blueLimit      uint
+	pixelSamples   uint
+	sampling       string
+	tile           config.ZoomBounds
 }
 
 // Parse command line arguments into a `commandLine' structure
@@ -65,7 +90,7 @@ func parseArguments() commandLine {
 	}
 	argbnds := make([]string, len(bnds))
 	for i, c := range bnds {
-		argbnds[i] = strconv.FormatFloat(c, 'e', -1, 64)
+		argbnds[i] = format(c)
 	}
 
 	flag.UintVar(&args.iterateLimit, "iterlim",
@@ -85,16 +110,65 @@ func parseArguments() commandLine {
 	flag.StringVar(&args.imagMax, "imax",
 		argbnds[3], "Topmost position on complex plane")
 	flag.StringVar(&args.mode, "render", "auto",
-		"Render mode.  (auto|sequence|region)")
+		"Render mode.  (auto|sequence|region|buddhabrot)")
 	flag.UintVar(&args.regionCollapse, "collapse",
 		godelbrot.DefaultCollapse, "Pixel width of region at which sequential render is forced")
 	flag.UintVar(&args.glitchSamples, "samples",
 		godelbrot.DefaultRegionSamples, "Size of region sample set")
 	flag.UintVar(&args.precision, "prec",
-		godelbrot.DefaultPrecision, "Precision for big.Float render mode")
+		godelbrot.DefaultPrecision, "Precision for big.Float and fixed-point render modes")
 	flag.StringVar(&args.numerics, "numerics",
-		"auto", "Numerical system (auto|native|bigfloat)")
-	flag.StringVar(&args.palette, "palette", "grayscale", "(redscale|grayscale|pretty)")
+		"auto", "Numerical system (auto|native|doubledouble|bigfloat|fixed|perturb)")
+	flag.StringVar(&args.palette, "palette", "grayscale",
+		fmt.Sprintf("(%v)", strings.Join(godelbrot.PaletteCodes(), "|")))
+	flag.StringVar(&args.gradient, "gradient", "",
+		"Gradient palette file, as JSON or GIMP .ggr (implies -palette gradient)")
+	flag.Float64Var(&args.paletteOffset, "paletteoffset", 0,
+		"Phase of the palette, as a fraction of its cycle")
+	flag.UintVar(&args.paletteCycle, "palettecycle", 0,
+		"Iterations in one cycle of the palette (0 means iterlim)")
+	flag.BoolVar(&args.smooth, "smooth", false,
+		"Interpolate colours to remove banding")
+	flag.BoolVar(&args.histogram, "histogram", false,
+		"Spread colours evenly by the histogram of escape values")
+	flag.BoolVar(&args.series, "series", false,
+		"Skip iterations using series approximation (perturb or auto numerics only)")
+	flag.UintVar(&args.seriesTerms, "seriesterms",
+		godelbrot.DefaultSeriesTerms, "Number of terms in approximating series")
+	flag.Float64Var(&args.seriesTol, "seriestol",
+		godelbrot.DefaultSeriesTolerance, "Relative error tolerated by series approximation")
+	flag.StringVar(&args.fractal, "fractal", "mandelbrot", "Fractal kind (mandelbrot|julia)")
+	flag.StringVar(&args.juliaReal, "jreal", "0", "Real part of Julia set constant")
+	flag.StringVar(&args.juliaImag, "jimag", "0", "Imaginary part of Julia set constant")
+	flag.UintVar(&args.exponent, "exponent", godelbrot.DefaultExponent,
+		"Power of z in z^n + c (Multibrot sets)")
+	flag.StringVar(&args.formula, "formula", godelbrot.DefaultFormulaCode,
+		fmt.Sprintf("Escape-time formula (%v)", strings.Join(godelbrot.FormulaCodes(), "|")))
+	flag.BoolVar(&args.cardioid, "cardioid", false,
+		"Skip points in the main cardioid and period-2 bulb (native and bigfloat numerics only)")
+	flag.BoolVar(&args.periodicity, "periodicity", false,
+		"Stop iterating cycling orbits (native and bigfloat numerics only)")
+	flag.StringVar(&args.shading, "shading", "none",
+		"Shade using the distance estimate (none|distance|relief)")
+	flag.StringVar(&args.interior, "interior", "flat",
+		"Colour points inside the set (flat|period|distance)")
+	flag.UintVar(&args.jobs, "jobs", 1, "Number of render threads")
+	flag.UintVar(&args.buddhaSamples, "buddhasamples", godelbrot.DefaultBuddhaSamples,
+		"Number of orbits plotted by the buddhabrot renderer")
+	flag.UintVar(&args.redLimit, "rlimit", 0,
+		"Red channel iteration limit for the buddhabrot renderer (0 means iterlim)")
+	flag.UintVar(&args.greenLimit, "glimit", 0,
+		"Green channel iteration limit for the buddhabrot renderer (0 means iterlim)")
+	flag.UintVar(&args.blueLimit, "blimit", 0,
+		"Blue channel iteration limit for the buddhabrot renderer (0 means iterlim)")
+	flag.UintVar(&args.pixelSamples, "pixelsamples", 1,
+		"Number of samples averaged for each pixel (a square number)")
+	flag.StringVar(&args.sampling, "sampling", "grid",
+		"Placement of samples within each pixel (grid|jitter)")
+	flag.UintVar(&args.tile.Xmin, "tilexmin", 0, "Left of the tile to render (pixels)")
+	flag.UintVar(&args.tile.Xmax, "tilexmax", 0, "Right of the tile to render (pixels, 0 means the right of the picture)")
+	flag.UintVar(&args.tile.Ymin, "tileymin", 0, "Top of the tile to render (pixels)")
+	flag.UintVar(&args.tile.Ymax, "tileymax", 0, "Bottom of the tile to render (pixels, 0 means the bottom of the picture)")
 	flag.StringVar(&args.fixAspect, "fix", "stretch", "Aspect ratio conservation (stretch|shrink|grow)")
 	flag.BoolVar(&args.reconfigure, "reconf", false,
 		"Reconfigure the render spec sent to stdin")
@@ -123,40 +197,121 @@ func newRequest(args commandLine) (*config.Request, error) {
 	}
 
 	argact := map[string]func(){
-		"fix":      func() { req.FixAspect = user.FixAspect },
-		"palette":  func() { req.PaletteCode = user.PaletteCode },
-		"numerics": func() { req.Numerics = user.Numerics },
-		"prec":     func() { req.Precision = user.Precision },
-		"jobs":     func() { req.Jobs = user.Jobs },
-		"collapse": func() { req.RegionCollapse = user.RegionCollapse },
-		"render":   func() { req.Renderer = user.Renderer },
-		"iterlim":  func() { req.IterateLimit = user.IterateLimit },
-		"divlim":   func() { req.DivergeLimit = user.DivergeLimit },
-		"width":    func() { req.ImageWidth = user.ImageWidth },
-		"height":   func() { req.ImageHeight = user.ImageHeight },
-		"rmin":     func() { req.RealMin = user.RealMin },
-		"rmax":     func() { req.RealMax = user.RealMax },
-		"imin":     func() { req.ImagMin = user.ImagMin },
-		"imax":     func() { req.ImagMax = user.ImagMax },
-		"samples":  func() { req.RegionSamples = user.RegionSamples },
-		"reconf":   func() {},
+		"fix":           func() { req.FixAspect = user.FixAspect },
+		"palette":       func() { req.PaletteCode = user.PaletteCode },
+		"gradient":      func() { req.Gradient, req.PaletteCode = user.Gradient, user.PaletteCode },
+		"paletteoffset": func() { req.PaletteOffset = user.PaletteOffset },
+		"palettecycle":  func() { req.PaletteCycle = user.PaletteCycle },
+		"numerics":      func() { req.Numerics = user.Numerics },
+		"prec":          func() { req.Precision = user.Precision },
+		"jobs":          func() { req.Jobs = user.Jobs },
+		"collapse":      func() { req.RegionCollapse = user.RegionCollapse },
+		"render":        func() { req.Renderer = user.Renderer },
+		"iterlim":       func() { req.IterateLimit = user.IterateLimit },
+		"divlim":        func() { req.DivergeLimit = user.DivergeLimit },
+		"width":         func() { req.ImageWidth = user.ImageWidth },
+		"height":        func() { req.ImageHeight = user.ImageHeight },
+		"rmin":          func() { req.RealMin = user.RealMin },
+		"rmax":          func() { req.RealMax = user.RealMax },
+		"imin":          func() { req.ImagMin = user.ImagMin },
+		"imax":          func() { req.ImagMax = user.ImagMax },
+		"samples":       func() { req.RegionSamples = user.RegionSamples },
+		"smooth":        func() { req.Smooth = user.Smooth },
+		"histogram":     func() { req.Histogram = user.Histogram },
+		"series":        func() { req.SeriesApproximation = user.SeriesApproximation },
+		"seriesterms":   func() { req.SeriesTerms = user.SeriesTerms },
+		"seriestol":     func() { req.SeriesTolerance = user.SeriesTolerance },
+		"fractal":       func() { req.Fractal = user.Fractal },
+		"jreal":         func() { req.JuliaReal = user.JuliaReal },
+		"jimag":         func() { req.JuliaImag = user.JuliaImag },
+		"exponent":      func() { req.Exponent = user.Exponent },
+		"formula":       func() { req.FormulaCode = user.FormulaCode },
+		"cardioid":      func() { req.CardioidCheck = user.CardioidCheck },
+		"periodicity":   func() { req.PeriodicityCheck = user.PeriodicityCheck },
+		"shading":       func() { req.Shading = user.Shading },
+		"interior":      func() { req.Interior = user.Interior },
+		"buddhasamples": func() { req.BuddhaSamples = user.BuddhaSamples },
+		"rlimit":        func() { req.RedIterateLimit = user.RedIterateLimit },
+		"glimit":        func() { req.GreenIterateLimit = user.GreenIterateLimit },
+		"blimit":        func() { req.BlueIterateLimit = user.BlueIterateLimit },
+		"pixelsamples":  func() { req.PixelSamples = user.PixelSamples },
+		"sampling":      func() { req.Sampling = user.Sampling },
+		"tilexmin":      func() { req.Tile.Xmin = user.Tile.Xmin },
+		"tilexmax":      func() { req.Tile.Xmax = user.Tile.Xmax },
+		"tileymin":      func() { req.Tile.Ymin = user.Tile.Ymin },
+		"tileymax":      func() { req.Tile.Ymax = user.Tile.Ymax },
+		"reconf":        func() {},
 	}
 
+	bounded := false
 	flag.Visit(func(fl *flag.Flag) {
 		act := argact[fl.Name]
 		if act == nil {
 			log.Fatal("BUG: unknown action ", fl.Name)
 		}
 		act()
+		switch fl.Name {
+		case "rmin", "rmax", "imin", "imax":
+			bounded = true
+		}
 	})
 
+	// Frame the whole fractal unless told otherwise
+	if !bounded && !args.reconfigure {
+		if req.Fractal == config.JuliaFractal {
+			// Julia sets are centred on the origin, so the Mandelbrot defaults do not suit them
+			min, max := godelbrot.JuliaBounds(req.Exponent)
+			req.RealMin = format(real(min))
+			req.ImagMin = format(imag(min))
+			req.RealMax = format(real(max))
+			req.ImagMax = format(imag(max))
+		} else {
+			frame := godelbrot.DefaultMultibrotRequest(req.Exponent)
+			req.RealMin, req.ImagMin = frame.RealMin, frame.ImagMin
+			req.RealMax, req.ImagMax = frame.RealMax, frame.ImagMax
+		}
+	}
+
+	// A tile reaches the right and bottom of the picture unless told otherwise
+	if req.Tile != (config.ZoomBounds{}) {
+		if req.Tile.Xmax == 0 {
+			req.Tile.Xmax = req.ImageWidth
+		}
+		if req.Tile.Ymax == 0 {
+			req.Tile.Ymax = req.ImageHeight
+		}
+	}
+
 	return req, nil
 }
 
+func readGradient(path string) (*config.Gradient, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(strings.ToLower(path), ".ggr") {
+		return godelbrot.ReadGIMPGradient(f)
+	}
+	return godelbrot.ReadGradient(f)
+}
+
 func userReq(args commandLine) (*config.Request, error) {
-	const max8 = uint(^uint8(0))
-	if args.iterateLimit > max8 {
-		return nil, fmt.Errorf("iterateLimit out of bounds.  Valid values in range (0,%v)", max8)
+	const max32 = uint(^uint32(0))
+	if args.iterateLimit > max32 {
+		return nil, fmt.Errorf("iterateLimit out of bounds.  Valid values in range (0,%v)", max32)
+	}
+
+	if args.paletteCycle > max32 {
+		return nil, fmt.Errorf("paletteCycle out of bounds.  Valid values in range [0,%v)", max32)
+	}
+
+	for _, limit := range []uint{args.redLimit, args.greenLimit, args.blueLimit} {
+		if limit > max32 {
+			return nil, fmt.Errorf("Channel limit out of bounds.  Valid values in range [0,%v)", max32)
+		}
 	}
 
 	if args.divergeLimit <= 0.0 {
@@ -168,6 +323,18 @@ func userReq(args commandLine) (*config.Request, error) {
 		return nil, fmt.Errorf("jobs out of bounds.  Valid values in range (0,%v)", max16)
 	}
 
+	if args.exponent < 2 {
+		return nil, fmt.Errorf("exponent out of bounds.  Valid values in range [2,)")
+	}
+
+	if args.series && args.seriesTerms == 0 {
+		return nil, fmt.Errorf("seriesTerms out of bounds.  Valid values in range (0,)")
+	}
+
+	if args.series && args.seriesTol <= 0.0 {
+		return nil, fmt.Errorf("seriesTol out of bounds.  Valid values in range (0,)")
+	}
+
 	numerics := config.AutoDetectNumericsMode
 	switch args.numerics {
 	case "auto":
@@ -176,10 +343,60 @@ func userReq(args commandLine) (*config.Request, error) {
 		numerics = config.BigFloatNumericsMode
 	case "native":
 		numerics = config.NativeNumericsMode
+	case "perturb":
+		numerics = config.PerturbationNumericsMode
+	case "doubledouble":
+		numerics = config.DoubleDoubleNumericsMode
+	case "fixed":
+		numerics = config.FixedPointNumericsMode
 	default:
 		return nil, fmt.Errorf("Unknown numerics mode: %v", args.numerics)
 	}
 
+	fractal := config.MandelbrotFractal
+	switch args.fractal {
+	case "mandelbrot":
+		// No change
+	case "julia":
+		fractal = config.JuliaFractal
+	default:
+		return nil, fmt.Errorf("Unknown fractal kind: %v", args.fractal)
+	}
+
+	shading := config.NoShading
+	switch args.shading {
+	case "none":
+		// No change
+	case "distance":
+		shading = config.DistanceShading
+	case "relief":
+		shading = config.ReliefShading
+	default:
+		return nil, fmt.Errorf("Unknown shading: %v", args.shading)
+	}
+
+	interior := config.FlatInterior
+	switch args.interior {
+	case "flat":
+		// No change
+	case "period":
+		interior = config.PeriodInterior
+	case "distance":
+		interior = config.DistanceInterior
+	default:
+		return nil, fmt.Errorf("Unknown interior colouring: %v", args.interior)
+	}
+
+	sampling := config.GridSampling
+	switch args.sampling {
+	case "grid":
+		// No change
+	case "jitter":
+		sampling = config.JitterSampling
+	default:
+		return nil, fmt.Errorf("Unknown sampling pattern: %v", args.sampling)
+	}
+
 	renderer := config.AutoDetectRenderMode
 	switch args.mode {
 	case "auto":
@@ -188,6 +405,8 @@ func userReq(args commandLine) (*config.Request, error) {
 		renderer = config.SequenceRenderMode
 	case "region":
 		renderer = config.RegionRenderMode
+	case "buddhabrot":
+		renderer = config.BuddhabrotRenderMode
 	default:
 		return nil, fmt.Errorf("Unknown render mode: %v", args.mode)
 	}
@@ -204,8 +423,19 @@ func userReq(args commandLine) (*config.Request, error) {
 		return nil, fmt.Errorf("Unknown aspect fix strategy: %v", args.fixAspect)
 	}
 
+	palette := args.palette
+	var gradient *config.Gradient
+	if args.gradient != "" {
+		grad, gerr := readGradient(args.gradient)
+		if gerr != nil {
+			return nil, fmt.Errorf("Error reading gradient: %v", gerr)
+		}
+		gradient = grad
+		palette = "gradient"
+	}
+
 	req := &config.Request{}
-	req.IterateLimit = uint8(args.iterateLimit)
+	req.IterateLimit = uint32(args.iterateLimit)
 	req.DivergeLimit = args.divergeLimit
 	req.RealMin = args.realMin
 	req.RealMax = args.realMax
@@ -213,13 +443,42 @@ func userReq(args commandLine) (*config.Request, error) {
 	req.ImagMax = args.imagMax
 	req.ImageWidth = args.width
 	req.ImageHeight = args.height
-	req.PaletteCode = args.palette
+	req.PaletteCode = palette
+	req.Gradient = gradient
+	req.PaletteOffset = args.paletteOffset
+	req.PaletteCycle = uint32(args.paletteCycle)
 	req.FixAspect = aspect
 	req.Renderer = renderer
 	req.Numerics = numerics
 	req.RegionCollapse = args.regionCollapse
 	req.RegionSamples = args.glitchSamples
 	req.Precision = args.precision
+	req.Smooth = args.smooth
+	req.Histogram = args.histogram
+	req.SeriesApproximation = args.series
+	req.SeriesTerms = args.seriesTerms
+	req.SeriesTolerance = args.seriesTol
+	req.Fractal = fractal
+	req.Exponent = args.exponent
+	req.FormulaCode = args.formula
+	req.CardioidCheck = args.cardioid
+	req.PeriodicityCheck = args.periodicity
+	req.Shading = shading
+	req.Interior = interior
+	req.Jobs = uint16(args.jobs)
+	req.BuddhaSamples = args.buddhaSamples
+	req.RedIterateLimit = uint32(args.redLimit)
+	req.GreenIterateLimit = uint32(args.greenLimit)
+	req.BlueIterateLimit = uint32(args.blueLimit)
+	req.PixelSamples = args.pixelSamples
+	req.Sampling = sampling
+	req.Tile = args.tile
+	req.JuliaReal = args.juliaReal
+	req.JuliaImag = args.juliaImag
 
 	return req, nil
 }
+
+func format(f float64) string {
+	return strconv.FormatFloat(f, 'e', -1, 64)
+}
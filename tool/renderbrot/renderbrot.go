@@ -1,39 +1,105 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	lib "github.com/johnny-morrice/godelbrot"
 	"image"
 	"image/png"
 	"io"
 	"log"
 	"os"
+	"os/signal"
 )
 
+// progressLine is the prefix of the progress lines written to stderr
+const progressLine = "Progress:"
+
+// printProgress writes progress to stderr whenever the phase or the whole percentage changes
+func printProgress() func(lib.Progress) {
+	last := lib.Progress{Phase: lib.SubdividePhase}
+	lastPercent := -1
+	return func(p lib.Progress) {
+		percent := int(p.Percent())
+		complete := p.Pixels == p.Total && p.Regions == 0
+		if p.Phase == last.Phase && percent == lastPercent && !complete {
+			return
+		}
+		last = p
+		lastPercent = percent
+		fmt.Fprintln(os.Stderr, progressLine, p)
+	}
+}
+
 func main() {
+	var progress, escapes bool
+	flag.BoolVar(&progress, "progress", false, "Print render progress to stderr")
+	flag.BoolVar(&escapes, "escapes", false, "Write an escape map for colorbrot rather than a PNG")
+	flag.Parse()
+
 	var input io.Reader = os.Stdin
 	var output io.Writer = os.Stdout
 
+	// Stop rendering on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	frch := lib.ReadInfoStream(input)
-	imgch := make(chan image.Image)
+	// Each result encodes itself on to the output
+	imgch := make(chan func(io.Writer) error)
 
 	go func() {
 		for frpkt := range frch {
 			if frpkt.Err != nil {
 				log.Fatal(frpkt.Err)
 			}
-			picture, renderErr := lib.Render(frpkt.Info)
+			renderer, makeErr := lib.MakeRenderer(frpkt.Info)
+
+			if makeErr != nil {
+				log.Fatal("Render errror:", makeErr)
+			}
+
+			if progress {
+				renderer.Observe(printProgress())
+			}
+
+			var encode func(io.Writer) error
+			var renderErr error
+			if escapes {
+				var em *lib.EscapeMap
+				em, renderErr = renderer.RenderEscapeMap(ctx)
+				encode = func(w io.Writer) error {
+					return lib.WriteEscapeMap(w, em)
+				}
+			} else {
+				var picture image.Image
+				picture, renderErr = renderer.RenderContext(ctx)
+				encode = func(w io.Writer) error {
+					return png.Encode(w, picture)
+				}
+			}
+
+			if renderErr == context.Canceled {
+				log.Fatal("Render cancelled")
+			}
 
 			if renderErr != nil {
 				log.Fatal("Render errror:", renderErr)
 			}
 
-			imgch <- picture
+			if frpkt.Info.UserRequest.SeriesApproximation {
+				report := renderer.Report()
+				log.Println("Series approximation skipped", report.SkippedIterations, "iterations")
+			}
+
+			imgch <- encode
 		}
 		close(imgch)
 	}()
 
-	for picture := range imgch {
-		encodeErr := png.Encode(output, picture)
+	for encode := range imgch {
+		encodeErr := encode(output)
 
 		if encodeErr != nil {
 			log.Fatal("Encoding error:", encodeErr)
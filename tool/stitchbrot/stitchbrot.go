@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A tile PNG and the position of its top left corner in the whole picture
+type tile struct {
+	path string
+	min  image.Point
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: stitchbrot XMIN,YMIN:TILE.png... > picture.png")
+	fmt.Fprintln(os.Stderr, "Reassemble tiles rendered with configbrot -tilexmin etc. into one picture.")
+	flag.PrintDefaults()
+}
+
+func parseTile(arg string) (tile, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 {
+		return tile{}, fmt.Errorf("Expected XMIN,YMIN:PATH but received: %v", arg)
+	}
+
+	coords := strings.Split(parts[0], ",")
+	if len(coords) != 2 {
+		return tile{}, fmt.Errorf("Expected XMIN,YMIN but received: %v", parts[0])
+	}
+
+	x, xerr := strconv.ParseUint(coords[0], 10, 32)
+	if xerr != nil {
+		return tile{}, xerr
+	}
+	y, yerr := strconv.ParseUint(coords[1], 10, 32)
+	if yerr != nil {
+		return tile{}, yerr
+	}
+
+	return tile{path: parts[1], min: image.Pt(int(x), int(y))}, nil
+}
+
+func readTile(t tile) (image.Image, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	tiles := make([]tile, flag.NArg())
+	for i, arg := range flag.Args() {
+		t, err := parseTile(arg)
+		if err != nil {
+			log.Fatal("Invalid tile: ", err)
+		}
+		tiles[i] = t
+	}
+
+	pictures := make([]image.Image, len(tiles))
+	bounds := image.ZR
+	for i, t := range tiles {
+		pic, err := readTile(t)
+		if err != nil {
+			log.Fatal("Error reading tile ", t.path, ": ", err)
+		}
+		pictures[i] = pic
+
+		size := pic.Bounds().Size()
+		bounds = bounds.Union(image.Rectangle{Min: t.min, Max: t.min.Add(size)})
+	}
+
+	// The whole picture starts at the origin, even when no tile does
+	whole := image.NewNRGBA(image.Rect(0, 0, bounds.Max.X, bounds.Max.Y))
+	for i, t := range tiles {
+		pic := pictures[i]
+		target := image.Rectangle{Min: t.min, Max: t.min.Add(pic.Bounds().Size())}
+		draw.Draw(whole, target, pic, pic.Bounds().Min, draw.Src)
+	}
+
+	encErr := png.Encode(os.Stdout, whole)
+
+	if encErr != nil {
+		log.Fatal("Error encoding PNG: ", encErr)
+	}
+}
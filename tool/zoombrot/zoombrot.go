@@ -44,6 +44,8 @@ func main() {
 func readArgs() params {
 	args := params{}
 	flag.UintVar(&args.zt.Frames, "frames", 1, "Number of frames in zoom")
+	flag.Float64Var(&args.zt.PaletteShift, "paletteshift", 0,
+		"Change in palette offset over the zoom, for colour cycling")
 	flag.UintVar(&args.zt.Xmin, "xmin", 0, "X-Min")
 	flag.UintVar(&args.zt.Xmax, "xmax", 0, "X-Max")
 	flag.UintVar(&args.zt.Ymin, "ymin", 0, "Y-Min")
@@ -12,6 +12,14 @@ func parseBig(number string) (*big.Float, error) {
 	return f, err
 }
 
+// Parse a big.Float, where the empty string means zero
+func parseOptionalBig(number string) (*big.Float, error) {
+	if number == "" {
+		return big.NewFloat(0.0), nil
+	}
+	return parseBig(number)
+}
+
 func emitBig(b *big.Float) string {
 	digits := bits2digits(b.MinPrec())
 	return b.Text('e', int(digits))
@@ -2,6 +2,7 @@ package godelbrot
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/johnny-morrice/godelbrot/config"
 	"github.com/johnny-morrice/godelbrot/internal/bigbase"
 	"io"
@@ -94,6 +95,7 @@ func (z *Zoom) lens(degree float64) *Info {
 	info.RealMax = *zoom[2]
 	info.ImagMax = *zoom[3]
 	info.UserRequest = info.GenRequest()
+	info.UserRequest.PaletteOffset += degree * z.PaletteShift
 
 	return info
 }
@@ -158,6 +160,37 @@ func (z *Zoom) Movie() ([]*Info, error) {
 	return frames, nil
 }
 
+// JuliaAt requests the Julia set whose seed is the point at pixel (x, y) of the Mandelbrot set
+// described by info.  The request frames the whole Julia set.
+func JuliaAt(info *Info, x, y int) (*config.Request, error) {
+	if info.UserRequest.Fractal != config.MandelbrotFractal {
+		return nil, fmt.Errorf("Julia sets are chosen from points of the Mandelbrot set")
+	}
+
+	// Changing the precision must not touch the caller's bignums
+	appinfo := info.clone()
+	appinfo.UserRequest.FixAspect = config.Stretch
+	// Short bounds such as "-2" are parsed with only a few bits
+	if appinfo.Precision < DefaultPrecision {
+		appinfo.AddPrec(int(DefaultPrecision - appinfo.Precision))
+	}
+	baseapp := makeBaseFacade(appinfo)
+	app := makeBigBaseFacade(appinfo, baseapp)
+	num := bigbase.Make(app)
+	seed := num.PixelToPlane(x, y)
+
+	req := info.GenRequest()
+	req.Fractal = config.JuliaFractal
+	req.JuliaReal = emitBig(&seed.R)
+	req.JuliaImag = emitBig(&seed.I)
+	min, max := JuliaBounds(req.Exponent)
+	req.RealMin = emitBig(big.NewFloat(real(min)))
+	req.ImagMin = emitBig(big.NewFloat(imag(min)))
+	req.RealMax = emitBig(big.NewFloat(real(max)))
+	req.ImagMax = emitBig(big.NewFloat(imag(max)))
+	return &req, nil
+}
+
 type UserZoom struct {
 	Prev UserInfo
 	ZoomTarget
@@ -1,7 +1,9 @@
 package godelbrot
 
 import (
+	"github.com/johnny-morrice/godelbrot/config"
 	"github.com/johnny-morrice/godelbrot/internal/bigbase"
+	"math"
 	"math/big"
 	"testing"
 )
@@ -230,3 +232,91 @@ func TestMovie(t *testing.T) {
 		}
 	}
 }
+
+func TestMoviePaletteShift(t *testing.T) {
+	const framecnt = 4
+
+	target := ZoomTarget{}
+	target.Xmin = 10
+	target.Xmax = 30
+	target.Ymin = 20
+	target.Ymax = 50
+	target.Frames = framecnt
+	target.PaletteShift = 1
+
+	req := DefaultRequest()
+	req.ImageWidth = 100
+	req.ImageHeight = 100
+	req.PaletteOffset = 0.5
+
+	z := Zoom{ZoomTarget: target}
+	prev, conferr := Configure(req)
+	if conferr != nil {
+		t.Fatal(conferr)
+	}
+	z.Prev = *prev
+
+	frames, magerr := z.Movie()
+	if magerr != nil {
+		t.Fatal(magerr)
+	}
+	for i, fr := range frames {
+		expect := 0.5 + (float64(i+1) / framecnt)
+		if actual := fr.UserRequest.PaletteOffset; math.Abs(actual-expect) > 0.000001 {
+			t.Error("Frame", i, "expected palette offset", expect, "but received", actual)
+		}
+	}
+}
+
+func TestJuliaAt(t *testing.T) {
+	req := DefaultRequest()
+	req.RealMin = "-2"
+	req.RealMax = "2"
+	req.ImagMin = "-2"
+	req.ImagMax = "2"
+	req.ImageWidth = 100
+	req.ImageHeight = 100
+	info, err := Configure(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := make([]string, 0, len(info.bignums()))
+	for _, x := range info.bignums() {
+		before = append(before, x.Text('p', 0))
+	}
+	prec := info.RealMin.Prec()
+
+	julia, err := JuliaAt(info, 25, 75)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range info.bignums() {
+		if actual := x.Text('p', 0); actual != before[i] {
+			t.Error("Expected JuliaAt to leave bignum", i, "as", before[i], "but received", actual)
+		}
+	}
+	if actual := info.RealMin.Prec(); actual != prec {
+		t.Error("Expected JuliaAt to leave precision", prec, "but received", actual)
+	}
+
+	if julia.Fractal != config.JuliaFractal {
+		t.Error("Expected Julia fractal but received", julia.Fractal)
+	}
+	if julia.JuliaReal != "-1.0e+00" || julia.JuliaImag != "-1.0e+00" {
+		t.Error("Expected seed -1-1i but received", julia.JuliaReal, julia.JuliaImag)
+	}
+
+	desc, err := Configure(julia)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min, _ := desc.RealMin.Float64(); min != real(JuliaMin) {
+		t.Error("Expected Julia set framed from", real(JuliaMin), "but received", min)
+	}
+
+	if _, err := JuliaAt(desc, 0, 0); err == nil {
+		t.Error("Expected error choosing a Julia set from a Julia set")
+	}
+}